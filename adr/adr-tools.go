@@ -0,0 +1,180 @@
+package adr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// adrToolsLinkPattern matches a link line in an adr-tools status section.
+// example match: "Superseded by [2. Use Rust](0002-use-rust.md)".
+var adrToolsLinkPattern = regexp.MustCompile(`^(.+?)\s+\[(\d+)\.\s*(.*?)\]\((.*?)\)\s*$`)
+
+// adrToolsDatePattern matches the date line adr-tools writes under the title.
+var adrToolsDatePattern = regexp.MustCompile(`^Date:\s*(\S+)\s*$`)
+
+// ParseADRTools reads a document written by adr-tools (github.com/npryce/adr-tools) into an ADR.
+// adr-tools documents are markdown, titled like "# 1. Title", with a "Date:" line under the title and a status
+// section holding the status alongside link lines, eg: "Superseded by [2. Use Rust](0002-use-rust.md)".
+// Links are mapped to typed links, and a superseded ADR's status points at its replacement, as adr-er writes it.
+// Returns parse warnings for recoverable problems, as Parse does.
+func ParseADRTools(filename string, content []byte) (*ADR, []ParseWarning, error) {
+	record, warnings, err := Parse(filename, content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := scanSections(StripFrontMatter(content), markups[render.DocumentFormatMarkdown])
+
+	// the date line sits in the preamble, which scanSections skips
+	if date := adrToolsDate(content); date != "" {
+		if record.Created, err = parseDate(date); err != nil {
+			warnings = append(warnings, ParseWarning{Field: "date", Kind: WarningInvalid, Reason: err.Error()})
+		}
+	}
+
+	// the status section mixes the status with link lines
+	for _, section := range doc.sections {
+		if section.key != sectionStatus {
+			continue
+		}
+
+		status, links, linkWarnings := parseADRToolsStatus(section)
+		warnings = append(warnings, linkWarnings...)
+
+		record.Status = status
+		record.Links = append(record.Links, links...)
+
+		break
+	}
+
+	// superseded ADRs are marked only by their link
+	if record.Status == "" {
+		for _, link := range record.Links {
+			if link.Type == linkTypeSupersededBy {
+				record.Status = StatusSuperseded
+
+				break
+			}
+		}
+	}
+
+	if record.Status == "" {
+		warnings = append(warnings, ParseWarning{Field: sectionStatus, Kind: WarningMissing, Reason: "no status found"})
+	}
+
+	// adr-tools statuses are the default lifecycle's
+	record.RelinkSuperseded(DefaultLifecycle())
+
+	return record, warnings, nil
+}
+
+// RelinkSuperseded writes a superseded ADR's status as the lifecycle's superseded status, pointing at its replacement
+// through the replacement's resolved link. adr-tools' "superseded" counts, whatever the lifecycle calls it.
+// it's a no-op for ADRs that aren't superseded, or lifecycles without a superseded status.
+func (adr *ADR) RelinkSuperseded(lifecycle *Lifecycle) {
+	status, ok := lifecycle.Superseded()
+	if !ok {
+		return
+	}
+
+	normalized, _ := lifecycle.Normalize(adr.Status)
+	if normalized != status && !strings.HasPrefix(strings.ToLower(adr.Status), StatusSuperseded) {
+		return
+	}
+
+	adr.Status = status
+
+	for _, link := range adr.Links {
+		if link.Type == linkTypeSupersededBy && link.TargetPath != "" {
+			adr.Status = fmt.Sprintf("%s by [%s](%s)", status, link.TargetTitle, link.TargetPath)
+
+			return
+		}
+	}
+}
+
+// adrToolsDate returns the value of the first "Date:" line before any section, or empty if there's none.
+func adrToolsDate(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "## ") {
+			return ""
+		}
+
+		if matches := adrToolsDatePattern.FindStringSubmatch(line); matches != nil {
+			return matches[1]
+		}
+	}
+
+	return ""
+}
+
+// parseADRToolsStatus splits an adr-tools status section into its status and links.
+// the status is the first line that isn't a link. link labels are matched against the built-in link types,
+// and anything else becomes a custom link carrying its label.
+func parseADRToolsStatus(section parsedSection) (string, []Link, []ParseWarning) {
+	var (
+		status   string
+		links    []Link
+		warnings []ParseWarning
+	)
+
+	lines := section.body
+	if section.inline != "" {
+		lines = append([]string{section.inline}, lines...)
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		matches := adrToolsLinkPattern.FindStringSubmatch(line)
+		if matches == nil {
+			if status == "" {
+				status = strings.ToLower(line)
+			} else {
+				warnings = append(warnings, ParseWarning{
+					Field:  sectionStatus,
+					Kind:   WarningUnrecognized,
+					Reason: fmt.Sprintf("ignored status line %q", line),
+				})
+			}
+
+			continue
+		}
+
+		target, err := strconv.Atoi(matches[2])
+		if err != nil {
+			continue
+		}
+
+		link := Link{
+			Type:        utils.Slugify(matches[1]),
+			Target:      target,
+			Label:       "",
+			TargetTitle: utils.PadValue(target, globals.NumericPadWidth) + ": " + strings.TrimSpace(matches[3]),
+			TargetPath:  matches[4],
+		}
+
+		// built-in types are stored by name, anything else keeps its label
+		if _, _, typeErr := ParseLinkType(matches[1]); typeErr != nil {
+			link.Label = strings.TrimSpace(matches[1])
+		}
+
+		links = append(links, link)
+	}
+
+	return status, links, warnings
+}
@@ -0,0 +1,86 @@
+package adr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseADRTools(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		filename   string
+		content    string
+		assertFunc func(t *testing.T, record *ADR, warnings []ParseWarning, err error)
+	}{
+		{
+			name:     "accepted with links",
+			filename: "0003-use-cockroachdb.md",
+			content: "# 3. Use CockroachDB\n\nDate: 2019-01-10\n\n## Status\n\nAccepted\n\n" +
+				"Supersedes [2. Use Postgres](0002-use-postgres.md)\n\nExtends [1. Record](0001-record.md)\n\n" +
+				"## Context\n\nScale.\n\n## Decision\n\nCockroach.\n\n## Consequences\n\nCost.\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, warnings)
+
+				assert.Equal(t, 3, record.Sequence)
+				assert.Equal(t, "Use CockroachDB", record.Title)
+				assert.Equal(t, StatusAccepted, record.Status)
+				assert.Equal(t, time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC), record.Created)
+				assert.Equal(t, "Scale.", record.Context)
+
+				assert.Equal(t, []Link{
+					{Type: LinkTypeSupersedes, Target: 2, TargetTitle: "0002: Use Postgres", TargetPath: "0002-use-postgres.md"},
+					{Type: "extends", Target: 1, Label: "Extends", TargetTitle: "0001: Record", TargetPath: "0001-record.md"},
+				}, record.Links)
+			},
+		},
+		{
+			name:     "superseded",
+			filename: "0002-use-postgres.md",
+			content: "# 2. Use Postgres\n\nDate: 2018-04-01\n\n## Status\n\nSuperseded by [3. Use CockroachDB](0003-use-cockroachdb.md)\n\n" +
+				"## Context\n\nctx\n\n## Decision\n\ndec\n\n## Consequences\n\ncons\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, warnings)
+
+				assert.Equal(t, "superseded by [0003: Use CockroachDB](0003-use-cockroachdb.md)", record.Status)
+				assert.Equal(t, []Link{{
+					Type: "superseded-by", Target: 3, TargetTitle: "0003: Use CockroachDB", TargetPath: "0003-use-cockroachdb.md",
+				}}, record.Links)
+
+				// imports take the configured superseded status
+				record.RelinkSuperseded(NewLifecycle([]string{"accepted", "retired"}, nil, nil).WithSuperseded("retired"))
+				assert.Equal(t, "retired by [0003: Use CockroachDB](0003-use-cockroachdb.md)", record.Status)
+			},
+		},
+		{
+			name:     "bad date and no status",
+			filename: "0004-broken.md",
+			content:  "# 4. Broken\n\nDate: someday\n\n## Status\n\n## Context\n\nctx\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.True(t, record.Created.IsZero())
+				assert.Empty(t, record.Status)
+
+				fields := make([]string, 0, len(warnings))
+				for _, warning := range warnings {
+					fields = append(fields, warning.Field)
+				}
+
+				assert.Contains(t, fields, "date")
+				assert.Contains(t, fields, sectionStatus)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record, warnings, err := ParseADRTools(test.filename, []byte(test.content))
+			test.assertFunc(t, record, warnings, err)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/therealkevinard/adr-er/globals"
 	io_document "github.com/therealkevinard/adr-er/io-document"
@@ -21,6 +22,34 @@ type ADR struct {
 	Decision     string
 	Status       string
 	Consequences string
+
+	// structured metadata. these are carried in the document's front matter.
+
+	// Created is the date the ADR was created
+	Created time.Time
+	// StatusChanged is the date of the ADR's last status change
+	StatusChanged time.Time
+	// Authors are the people who wrote the ADR
+	Authors []string
+	// Deciders are the people who made the decision
+	Deciders []string
+	// Tags are free-form labels for grouping and querying ADRs
+	Tags []string
+	// Links are relationships to other ADRs
+	Links []Link
+	// StatusHistory records the ADR's status transitions, oldest first
+	StatusHistory []StatusChange
+
+	// MADR fields. see https://adr.github.io/madr/
+
+	// ConsideredOptions are the options that were weighed, one per entry
+	ConsideredOptions []string
+	// ProsAndCons discusses each considered option
+	ProsAndCons string
+	// Consulted are the people whose opinions were sought
+	Consulted []string
+	// Informed are the people kept up to date on the decision
+	Informed []string
 }
 
 // BuildDocument creates an IODocument from the ADR using the provided template.
@@ -56,7 +85,7 @@ func (adr *ADR) render(parsedTemplate *render.ParsedTemplateFile) ([]byte, error
 		return nil, fmt.Errorf("refusing to render invalid template: %w", err)
 	}
 
-	tpl, err := template.New(parsedTemplate.ID).Parse(string(parsedTemplate.Content))
+	tpl, err := parsedTemplate.Prepare(templateFuncs(parsedTemplate.Format))
 	if err != nil {
 		return nil, fmt.Errorf("error preparing template: %w", err)
 	}
@@ -68,3 +97,34 @@ func (adr *ADR) render(parsedTemplate *render.ParsedTemplateFile) ([]byte, error
 
 	return content.Bytes(), nil
 }
+
+// templateFuncs returns render.FuncMap, plus helpers for linking to other ADRs:
+//
+//   - linksOfType TYPE LINKS: the links of one type, eg: `{{range linksOfType "supersedes" .Links}}`
+//   - frontMatter ADR: the ADR's front matter, commented out as the format needs, eg: `{{frontMatter .}}`
+//   - adrLink LINK: a link to the target ADR, titled with its sequenced title, eg: "[0004: Old Decision](0004-old-decision.md)"
+func templateFuncs(format render.DocumentFormat) template.FuncMap {
+	funcs := render.FuncMap(format)
+
+	funcs["linksOfType"] = func(linkType string, links []Link) []Link {
+		var matched []Link
+
+		for _, link := range links {
+			if link.Type == linkType {
+				matched = append(matched, link)
+			}
+		}
+
+		return matched
+	}
+
+	funcs["adrLink"] = func(link Link) any { return render.Trusted(format, link.TargetMarkup(format)) }
+
+	funcs["frontMatter"] = func(adr *ADR) (any, error) {
+		block, err := adr.fencedFrontMatter(format)
+
+		return render.Trusted(format, block), err
+	}
+
+	return funcs
+}
@@ -2,6 +2,7 @@ package adr
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +74,65 @@ func TestBuildDocument(t *testing.T) {
 		})
 	}
 }
+
+// asserts custom templates can use the template functions, including the link helpers.
+func TestBuildDocument_TemplateFuncs(t *testing.T) {
+	tpl := &render.ParsedTemplateFile{
+		ID:     "custom",
+		Name:   "custom.markdown.tpl",
+		Format: render.DocumentFormatMarkdown,
+		Content: []byte(`# {{.Sequence | pad 3}} {{.Title | upper}}
+{{section "Context" .Context -}}
+{{section "Decision" .Decision -}}
+{{- range linksOfType "supersedes" .Links}}
+Replaces {{adrLink .}}
+{{- end}}`),
+	}
+
+	record := &ADR{
+		Sequence: 5,
+		Title:    "Use Rust",
+		Context:  "speed",
+		Links: []Link{
+			{Type: "supersedes", Target: 2, TargetTitle: "0002: Use Go", TargetPath: "0002-use-go.md"},
+			{Type: "relates-to", Target: 3},
+			{Type: "supersedes", Target: 1},
+		},
+	}
+
+	doc, err := record.BuildDocument(tpl)
+	require.NoError(t, err)
+	assert.Equal(t, "# 005 USE RUST\n\n## Context\n\nspeed\n\nReplaces [0002: Use Go](0002-use-go.md)\nReplaces 0001", string(doc.Content))
+}
+
+// asserts every built-in template renders a document ending in a newline, with or without optional sections.
+func TestBuildDocument_BuiltInTemplates(t *testing.T) {
+	tpls, err := render.ListTemplates()
+	require.NoError(t, err)
+
+	records := map[string]*ADR{
+		"bare": {Sequence: 1, Title: "Use Postgres", Status: StatusProposed},
+		"full": {
+			Sequence:          2,
+			Title:             "Use Postgres",
+			Context:           "we need a database",
+			Decision:          "postgres",
+			Status:            StatusAccepted,
+			Consequences:      "we run it",
+			ConsideredOptions: []string{"postgres", "mysql"},
+			ProsAndCons:       "it's boring",
+			Links:             []Link{{Type: LinkTypeAmends, Target: 1, TargetTitle: "0001: Use Postgres"}},
+		},
+	}
+
+	for name, tpl := range tpls {
+		for kind, record := range records {
+			t.Run(name+"/"+kind, func(t *testing.T) {
+				doc, err := record.BuildDocument(tpl)
+				require.NoError(t, err)
+
+				assert.True(t, strings.HasSuffix(string(doc.Content), "\n"), "missing trailing newline")
+			})
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// File is an ADR loaded from disk, along with where it came from and anything odd found while parsing it.
+type File struct {
+	*ADR
+	// Path is the full path to the ADR file
+	Path string
+	// Warnings holds the parse warnings for the file
+	Warnings []ParseWarning
+}
+
+// LoadDirectory parses every ADR file in dir, returning them ordered by sequence number.
+// Files that don't follow the ADR naming convention, or that aren't in a supported format, are skipped.
+// Subdirectories are not searched.
+func LoadDirectory(dir string) ([]File, error) {
+	if dir == "" {
+		return nil, globals.ValidationError("directory", "directory path is empty")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %w", err)
+	}
+
+	files := make([]File, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// only ADR-named files
+		if _, seqErr := utils.SequenceFromFilename(entry.Name()); seqErr != nil {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		record, warnings, loadErr := Load(path)
+		if loadErr != nil {
+			// unsupported formats aren't ADRs we can read. anything else is a real problem.
+			var validationErr globals.InputValidationError
+			if errors.As(loadErr, &validationErr) && validationErr.Field == "format" {
+				continue
+			}
+
+			return nil, loadErr
+		}
+
+		files = append(files, File{ADR: record, Path: path, Warnings: warnings})
+	}
+
+	slices.SortStableFunc(files, func(a, b File) int { return a.Sequence - b.Sequence })
+
+	return files, nil
+}
+
+// FindFile locates a single ADR in dir by reference: either its sequence number (eg: "7" or "0007"), or a slug matching
+// its filename or title (eg: "0007-team-expansion" or "team-expansion"). filenames may keep their document extension,
+// eg: "0007-team-expansion.md".
+// Returns an error if no ADR, or more than one, matches.
+func FindFile(dir, reference string) (string, error) {
+	if _, isDocument := render.FormatForExtension(filepath.Ext(reference)); isDocument {
+		reference = strings.TrimSuffix(filepath.Base(reference), filepath.Ext(reference))
+	}
+
+	if sequence, err := strconv.Atoi(reference); err == nil {
+		path, findErr := utils.FindBySequence(dir, sequence)
+		if findErr != nil {
+			return "", fmt.Errorf("error locating ADR: %w", findErr)
+		}
+
+		return path, nil
+	}
+
+	files, err := LoadDirectory(dir)
+	if err != nil {
+		return "", fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	slug := utils.Slugify(reference)
+
+	var found []string
+
+	for _, file := range files {
+		basename := strings.TrimSuffix(filepath.Base(file.Path), filepath.Ext(file.Path))
+		if slug == basename || slug == utils.Slugify(file.Title) {
+			found = append(found, file.Path)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", globals.ValidationError("reference", fmt.Sprintf("no ADR matches %q", reference))
+	case 1:
+		return found[0], nil
+	default:
+		return "", globals.ValidationError("reference", fmt.Sprintf("%q is ambiguous: %d ADRs match", reference, len(found)))
+	}
+}
@@ -0,0 +1,125 @@
+package adr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"0010-tenth.md":  "0010: Tenth\n---\n\n## Status: accepted\n",
+		"0002-second.md": "0002: Second\n---\n\n## Status: proposed\n",
+		"0003-notes.bin": "not an adr format",
+		"notes.md":       "not an adr name",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "0004-evidence.md"), 0o700))
+
+	loaded, err := LoadDirectory(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+
+	assert.Equal(t, 2, loaded[0].Sequence)
+	assert.Equal(t, "Second", loaded[0].Title)
+	assert.Equal(t, filepath.Join(dir, "0002-second.md"), loaded[0].Path)
+	assert.NotEmpty(t, loaded[0].Warnings)
+
+	assert.Equal(t, 10, loaded[1].Sequence)
+	assert.Equal(t, "accepted", loaded[1].Status)
+
+	_, err = LoadDirectory("")
+	require.Error(t, err)
+}
+
+func TestFindFile(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"0001-use-go.md":         "0001: Use Go\n---\n\n## Status: accepted\n",
+		"0002-team-expansion.md": "0002: Team Expansion\n---\n\n## Status: proposed\n",
+		"0003-renamed.md":        "0003: Use Go\n---\n\n## Status: proposed\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		reference  string
+		assertFunc func(t *testing.T, path string, err error)
+	}{
+		{
+			name:      "padded sequence",
+			reference: "0002",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(dir, "0002-team-expansion.md"), path)
+			},
+		},
+		{
+			name:      "filename slug",
+			reference: "0003-renamed",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(dir, "0003-renamed.md"), path)
+			},
+		},
+		{
+			name:      "filename",
+			reference: "0003-renamed.md",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(dir, "0003-renamed.md"), path)
+			},
+		},
+		{
+			name:      "filename with its path",
+			reference: filepath.Join("docs", "adr", "0002-team-expansion.md"),
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(dir, "0002-team-expansion.md"), path)
+			},
+		},
+		{
+			name:      "title slug",
+			reference: "Team Expansion",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(dir, "0002-team-expansion.md"), path)
+			},
+		},
+		{
+			name:      "ambiguous",
+			reference: "use-go",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.Error(t, err)
+				assert.Empty(t, path)
+			},
+		},
+		{
+			name:      "no match",
+			reference: "nothing",
+			assertFunc: func(t *testing.T, path string, err error) {
+				require.Error(t, err)
+				assert.Empty(t, path)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := FindFile(dir, test.reference)
+			test.assertFunc(t, path, err)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package adr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+// ExportSchemaVersion versions the Export layout. it's bumped whenever a field is removed, renamed, or changes meaning.
+// new fields may be added without a bump, so consumers should ignore keys they don't know.
+const ExportSchemaVersion = 1
+
+// Export formats.
+const (
+	ExportFormatJSON = "json"
+	ExportFormatYAML = "yaml"
+)
+
+// Export is the whole decision log as data, for tools that would rather not parse documents.
+type Export struct {
+	// SchemaVersion is the ExportSchemaVersion the export was written with
+	SchemaVersion int `json:"schema_version" yaml:"schema_version"`
+	// Records holds every ADR, ordered by sequence
+	Records []ExportRecord `json:"records" yaml:"records"`
+}
+
+// ExportRecord is a single ADR within an Export.
+// list fields are always present, and empty rather than null.
+type ExportRecord struct {
+	Sequence int    `json:"sequence" yaml:"sequence"`
+	Title    string `json:"title" yaml:"title"`
+	// Status is the normalized status, eg: "superseded"
+	Status string `json:"status" yaml:"status"`
+	// StatusText is the status as written, eg: "superseded by [0019: ...](...)"
+	StatusText string `json:"status_text" yaml:"status_text"`
+	// Active and Terminal are the status's flags, as the lifecycle defines them
+	Active   bool `json:"active" yaml:"active"`
+	Terminal bool `json:"terminal" yaml:"terminal"`
+
+	Sections ExportSections `json:"sections" yaml:"sections"`
+
+	// dates are YYYY-MM-DD, or empty when unknown
+	Created       string         `json:"created" yaml:"created"`
+	StatusChanged string         `json:"status_changed" yaml:"status_changed"`
+	StatusHistory []ExportChange `json:"status_history" yaml:"status_history"`
+	Authors       []string       `json:"authors" yaml:"authors"`
+	Deciders      []string       `json:"deciders" yaml:"deciders"`
+	Consulted     []string       `json:"consulted" yaml:"consulted"`
+	Informed      []string       `json:"informed" yaml:"informed"`
+	Tags          []string       `json:"tags" yaml:"tags"`
+	Links         []ExportLink   `json:"links" yaml:"links"`
+
+	// Path is where the ADR file lives
+	Path string `json:"path" yaml:"path"`
+	// ContentHash is the sha256 of the file's bytes, as "sha256:<hex>". it changes whenever the file does.
+	ContentHash string `json:"content_hash" yaml:"content_hash"`
+	// Warnings holds anything odd found while parsing the file
+	Warnings []string `json:"warnings" yaml:"warnings"`
+}
+
+// ExportSections holds an ADR's body sections.
+type ExportSections struct {
+	Context      string `json:"context" yaml:"context"`
+	Decision     string `json:"decision" yaml:"decision"`
+	Consequences string `json:"consequences" yaml:"consequences"`
+	// MADR sections, empty for other templates
+	ConsideredOptions []string `json:"considered_options" yaml:"considered_options"`
+	ProsAndCons       string   `json:"pros_and_cons" yaml:"pros_and_cons"`
+}
+
+// ExportChange is a single status transition.
+type ExportChange struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+	// At is an RFC3339 timestamp
+	At string `json:"at" yaml:"at"`
+}
+
+// ExportLink is a typed link to another ADR.
+type ExportLink struct {
+	// Type is the stored link type, eg: "superseded-by"
+	Type string `json:"type" yaml:"type"`
+	// Label is the human label, eg: "Superseded by"
+	Label  string `json:"label" yaml:"label"`
+	Target int    `json:"target" yaml:"target"`
+}
+
+// NewExport builds the export for files. each file is read again to hash its content.
+// displayPath maps each file's path to the path written in the export.
+func NewExport(files []File, lifecycle *Lifecycle, displayPath func(string) string) (*Export, error) {
+	export := &Export{
+		SchemaVersion: ExportSchemaVersion,
+		Records:       make([]ExportRecord, 0, len(files)),
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ADR: %w", err)
+		}
+
+		hash := sha256.Sum256(content)
+
+		record := ExportRecord{
+			Sequence:   file.Sequence,
+			Title:      file.Title,
+			Status:     lifecycle.Label(file.Status),
+			StatusText: file.Status,
+			Active:     lifecycle.IsActive(file.Status),
+			Terminal:   lifecycle.IsTerminal(file.Status),
+			Sections: ExportSections{
+				Context:           file.Context,
+				Decision:          file.Decision,
+				Consequences:      file.Consequences,
+				ConsideredOptions: nonNil(file.ConsideredOptions),
+				ProsAndCons:       file.ProsAndCons,
+			},
+			Created:       formatDate(file.Created),
+			StatusChanged: formatDate(file.StatusChanged),
+			StatusHistory: make([]ExportChange, 0, len(file.StatusHistory)),
+			Authors:       nonNil(file.Authors),
+			Deciders:      nonNil(file.Deciders),
+			Consulted:     nonNil(file.Consulted),
+			Informed:      nonNil(file.Informed),
+			Tags:          nonNil(file.Tags),
+			Links:         make([]ExportLink, 0, len(file.Links)),
+			Path:          displayPath(file.Path),
+			ContentHash:   "sha256:" + hex.EncodeToString(hash[:]),
+			Warnings:      make([]string, 0, len(file.Warnings)),
+		}
+
+		for _, change := range file.StatusHistory {
+			record.StatusHistory = append(record.StatusHistory, ExportChange{
+				From: change.From,
+				To:   change.To,
+				At:   change.At.Format(time.RFC3339),
+			})
+		}
+
+		for _, link := range file.Links {
+			record.Links = append(record.Links, ExportLink{Type: link.Type, Label: link.DisplayLabel(), Target: link.Target})
+		}
+
+		for _, warning := range file.Warnings {
+			record.Warnings = append(record.Warnings, warning.String())
+		}
+
+		export.Records = append(export.Records, record)
+	}
+
+	return export, nil
+}
+
+// Encode serializes the export in the given format.
+func (e *Export) Encode(format string) ([]byte, error) {
+	switch format {
+	case ExportFormatJSON:
+		encoded, err := json.MarshalIndent(e, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding json: %w", err)
+		}
+
+		return append(encoded, '\n'), nil
+
+	case ExportFormatYAML:
+		return encodeYAML(e)
+
+	default:
+		return nil, globals.ValidationError("format", fmt.Sprintf("unsupported export format %q", format))
+	}
+}
+
+// nonNil returns values, or an empty slice in place of nil.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -0,0 +1,67 @@
+package adr
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+
+	content := "---\ndate: 2024-03-01\ntags:\n  - infra\nlinks:\n  - type: superseded-by\n    target: 2\n---\n" +
+		"0001: Use Go\n---\n\n## Status: superseded by [0002](0002-use-rust.md)\n\n" +
+		"## Context\nctx\n\n## Decision\ndec\n\n## Consequences\ncons\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0001-use-go.md"), []byte(content), 0o600))
+
+	files, err := LoadDirectory(dir)
+	require.NoError(t, err)
+
+	export, err := NewExport(files, DefaultLifecycle(), filepath.Base)
+	require.NoError(t, err)
+
+	assert.Equal(t, ExportSchemaVersion, export.SchemaVersion)
+	require.Len(t, export.Records, 1)
+
+	record := export.Records[0]
+	assert.Equal(t, 1, record.Sequence)
+	assert.Equal(t, "Use Go", record.Title)
+	assert.Equal(t, StatusSuperseded, record.Status)
+	assert.Equal(t, "superseded by [0002](0002-use-rust.md)", record.StatusText)
+	assert.False(t, record.Active)
+	assert.True(t, record.Terminal)
+	assert.Equal(t, ExportSections{Context: "ctx", Decision: "dec", Consequences: "cons", ConsideredOptions: []string{}}, record.Sections)
+	assert.Equal(t, "2024-03-01", record.Created)
+	assert.Equal(t, []ExportLink{{Type: "superseded-by", Label: "Superseded by", Target: 2}}, record.Links)
+	assert.Equal(t, "0001-use-go.md", record.Path)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, record.ContentHash)
+	assert.Equal(t, []string{}, record.Authors)
+
+	tests := []struct {
+		format    string
+		unmarshal func(data []byte, v any) error
+	}{
+		{format: ExportFormatJSON, unmarshal: json.Unmarshal},
+		{format: ExportFormatYAML, unmarshal: yaml.Unmarshal},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			encoded, encodeErr := export.Encode(test.format)
+			require.NoError(t, encodeErr)
+
+			// it reads back as the same export
+			var decoded Export
+			require.NoError(t, test.unmarshal(encoded, &decoded))
+			assert.Equal(t, *export, decoded)
+		})
+	}
+
+	_, err = export.Encode("xml")
+	require.Error(t, err)
+}
@@ -0,0 +1,314 @@
+package adr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/render"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter opens and closes a yaml front matter block.
+const frontMatterDelimiter = "---"
+
+// frontMatter is the serialized form of an ADR's structured metadata.
+// dates are stored as plain YYYY-MM-DD strings, keeping hand-edits simple.
+type frontMatter struct {
+	Status        string   `yaml:"status,omitempty"`
+	Date          string   `yaml:"date,omitempty"`
+	StatusChanged string   `yaml:"status-changed,omitempty"`
+	Authors       []string `yaml:"authors,omitempty"`
+	Deciders      []string `yaml:"deciders,omitempty"`
+	// DecisionMakers is MADR 4's name for deciders. it's read, but never written.
+	DecisionMakers []string `yaml:"decision-makers,omitempty"`
+	Consulted      []string `yaml:"consulted,omitempty"`
+	Informed       []string `yaml:"informed,omitempty"`
+	Tags           []string `yaml:"tags,omitempty"`
+	Links          []Link   `yaml:"links,omitempty"`
+
+	History []statusChangeFrontMatter `yaml:"history,omitempty"`
+}
+
+// statusChangeFrontMatter is the serialized form of a StatusChange.
+// unlike the date fields, history keeps full RFC3339 timestamps so same-day transitions stay ordered.
+type statusChangeFrontMatter struct {
+	From string `yaml:"from,omitempty"`
+	To   string `yaml:"to"`
+	At   string `yaml:"at"`
+}
+
+// HasMetadata reports whether the ADR carries any structured metadata worth writing to front matter.
+func (adr *ADR) HasMetadata() bool {
+	return !adr.Created.IsZero() ||
+		!adr.StatusChanged.IsZero() ||
+		len(adr.Authors) > 0 ||
+		len(adr.Deciders) > 0 ||
+		len(adr.Consulted) > 0 ||
+		len(adr.Informed) > 0 ||
+		len(adr.Tags) > 0 ||
+		len(adr.Links) > 0 ||
+		len(adr.StatusHistory) > 0
+}
+
+// FrontMatter renders the ADR's metadata as a delimited yaml front matter block, followed by a blank line.
+// Returns an empty string if the ADR has no metadata, so templates can emit it unconditionally.
+func (adr *ADR) FrontMatter() (string, error) {
+	if !adr.HasMetadata() {
+		return "", nil
+	}
+
+	encoded, err := marshalFrontMatter(adr.frontMatter())
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded) + "\n", nil
+}
+
+// fencedFrontMatter renders the ADR's metadata as a front matter block for the given format, wrapped in a comment as
+// the format needs (see markup), followed by a blank line. Returns an empty string if the ADR has no metadata.
+func (adr *ADR) fencedFrontMatter(format render.DocumentFormat) (string, error) {
+	if !adr.HasMetadata() {
+		return "", nil
+	}
+
+	encoded, err := encodeYAML(adr.frontMatter())
+	if err != nil {
+		return "", err
+	}
+
+	block, err := markups[format].frontMatterBlock(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	return string(block) + "\n", nil
+}
+
+// frontMatter builds the serializable metadata for this ADR.
+func (adr *ADR) frontMatter() frontMatter {
+	history := make([]statusChangeFrontMatter, 0, len(adr.StatusHistory))
+	for _, change := range adr.StatusHistory {
+		history = append(history, statusChangeFrontMatter{
+			From: change.From,
+			To:   change.To,
+			At:   change.At.Format(time.RFC3339),
+		})
+	}
+
+	return frontMatter{
+		Status:         adr.Status,
+		Date:           formatDate(adr.Created),
+		StatusChanged:  formatDate(adr.StatusChanged),
+		Authors:        adr.Authors,
+		Deciders:       adr.Deciders,
+		DecisionMakers: nil,
+		Consulted:      adr.Consulted,
+		Informed:       adr.Informed,
+		Tags:           adr.Tags,
+		Links:          adr.Links,
+		History:        history,
+	}
+}
+
+// applyFrontMatter copies parsed metadata onto the ADR, returning warnings for values that can't be used.
+func (adr *ADR) applyFrontMatter(meta frontMatter) []ParseWarning {
+	var (
+		warnings []ParseWarning
+		err      error
+	)
+
+	if adr.Created, err = parseDate(meta.Date); err != nil {
+		warnings = append(warnings, ParseWarning{Field: "date", Kind: WarningInvalid, Reason: err.Error()})
+	}
+
+	if adr.StatusChanged, err = parseDate(meta.StatusChanged); err != nil {
+		warnings = append(warnings, ParseWarning{Field: "status-changed", Kind: WarningInvalid, Reason: err.Error()})
+	}
+
+	adr.Authors = meta.Authors
+	adr.Deciders = meta.Deciders
+	if len(adr.Deciders) == 0 {
+		adr.Deciders = meta.DecisionMakers
+	}
+
+	adr.Consulted = meta.Consulted
+	adr.Informed = meta.Informed
+	adr.Tags = meta.Tags
+	adr.Links = meta.Links
+
+	for _, change := range meta.History {
+		at, timeErr := time.Parse(time.RFC3339, change.At)
+		if timeErr != nil {
+			warnings = append(warnings, ParseWarning{
+				Field:  "history",
+				Kind:   WarningInvalid,
+				Reason: fmt.Sprintf("invalid timestamp %q, expected RFC3339", change.At),
+			})
+
+			continue
+		}
+
+		adr.StatusHistory = append(adr.StatusHistory, StatusChange{From: change.From, To: change.To, At: at})
+	}
+
+	return warnings
+}
+
+// marshalFrontMatter encodes metadata as yaml wrapped in front matter delimiters.
+func marshalFrontMatter(meta frontMatter) ([]byte, error) {
+	encoded, err := encodeYAML(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var block bytes.Buffer
+
+	block.WriteString(frontMatterDelimiter + "\n")
+	block.Write(encoded)
+	block.WriteString(frontMatterDelimiter + "\n")
+
+	return block.Bytes(), nil
+}
+
+// encodeYAML encodes v as yaml with two-space indentation, matching what folks tend to write by hand.
+func encodeYAML(v any) ([]byte, error) {
+	var encoded bytes.Buffer
+
+	encoder := yaml.NewEncoder(&encoded)
+	encoder.SetIndent(2) //nolint:mnd // not magic
+
+	if err := encoder.Encode(v); err != nil {
+		return nil, fmt.Errorf("error encoding yaml: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("error encoding yaml: %w", err)
+	}
+
+	return encoded.Bytes(), nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// StripFrontMatter returns content without its leading front matter block, if it has one.
+func StripFrontMatter(content []byte) []byte {
+	_, body, _ := splitFrontMatter(content)
+
+	return body
+}
+
+// splitFrontMatter separates a leading front matter block from the document body.
+// the block must open on the very first line, either bare or wrapped in a format's comment fence (see markup).
+// Returns the raw yaml (without delimiters or fence indentation), the remaining body, and whether a block was found.
+// an unterminated block is treated as no front matter at all.
+func splitFrontMatter(content []byte) ([]byte, []byte, bool) {
+	reader := bufio.NewReader(bytes.NewReader(content))
+
+	// the opening delimiter must be the first line, unless it's fenced
+	first, err := reader.ReadString('\n')
+	offset := len(first)
+
+	fence, fenced := frontMatterFence(strings.TrimRight(first, "\r\n"))
+	if fenced && fence.frontMatterOpen != "" {
+		first, err = reader.ReadString('\n')
+		offset += len(first)
+	}
+
+	// fenced lines may be indented. blank lines carry the indent without its trailing spaces, see markup.frontMatterBlock
+	unfence := func(line string) string {
+		line = strings.TrimRight(line, "\r\n")
+		if line == strings.TrimRight(fence.frontMatterIndent, " ") {
+			return ""
+		}
+
+		return strings.TrimPrefix(line, fence.frontMatterIndent)
+	}
+
+	if err != nil || unfence(first) != frontMatterDelimiter {
+		return nil, content, false
+	}
+
+	var yamlBlock bytes.Buffer
+
+	for {
+		line, readErr := reader.ReadString('\n')
+		offset += len(line)
+
+		if unfence(line) == frontMatterDelimiter {
+			break
+		}
+
+		if readErr != nil {
+			// ran out of content without a closing delimiter
+			return nil, content, false
+		}
+
+		yamlBlock.WriteString(unfence(line) + "\n")
+	}
+
+	// a fence that was opened must be closed right after the closing delimiter
+	if fenced && fence.frontMatterOpen != "" {
+		line, _ := reader.ReadString('\n')
+		if strings.TrimSpace(line) != fence.frontMatterClose {
+			return nil, content, false
+		}
+
+		offset += len(line)
+	}
+
+	return yamlBlock.Bytes(), content[offset:], true
+}
+
+// frontMatterFence returns the syntax of the format whose front matter fence is opened by line.
+// fences are opened by their own line, or for formats that only indent front matter, by the indented delimiter.
+func frontMatterFence(line string) (markup, bool) {
+	for _, syntax := range markups {
+		if syntax.frontMatterOpen != "" && line == syntax.frontMatterOpen {
+			return syntax, true
+		}
+
+		if syntax.frontMatterOpen == "" && syntax.frontMatterIndent != "" && line == syntax.frontMatterIndent+frontMatterDelimiter {
+			return syntax, true
+		}
+	}
+
+	return markup{}, false
+}
+
+// formatDate renders t as YYYY-MM-DD, or an empty string for the zero time.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.DateOnly)
+}
+
+// parseDate parses a YYYY-MM-DD date. an empty value returns the zero time.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,121 @@
+package adr
+
+import (
+	"slices"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// BuildGraph models files and the links between them as a graph, with one node per ADR and one edge per link.
+// Links are stored on both ADRs they join, so each pair is drawn once, in its forward direction:
+// built-in reverse links (eg: "superseded-by") are flipped, symmetric links are drawn from the older ADR, and custom
+// links held by both ADRs are drawn from the newer one, which is where forward links are usually made.
+// Links to ADRs that aren't in files get a bare node of their own.
+func BuildGraph(files []File, lifecycle *Lifecycle) render.Graph {
+	graph := render.Graph{
+		Nodes: make([]render.GraphNode, 0, len(files)),
+		Edges: nil,
+	}
+
+	known := make(map[int]*ADR, len(files))
+	for _, file := range files {
+		known[file.Sequence] = file.ADR
+
+		graph.Nodes = append(graph.Nodes, render.GraphNode{
+			ID:       nodeID(file.Sequence),
+			Label:    file.SequencedTitle(),
+			Status:   lifecycle.Label(file.Status),
+			Color:    lifecycle.Color(file.Status),
+			Terminal: lifecycle.IsTerminal(file.Status),
+		})
+	}
+
+	// edges, deduplicated on their drawn form
+	seen := make(map[render.GraphEdge]bool)
+
+	for _, file := range files {
+		for _, link := range file.Links {
+			from, to, label, ok := edgeDirection(file.ADR, link, known)
+			if !ok {
+				continue
+			}
+
+			edge := render.GraphEdge{From: nodeID(from), To: nodeID(to), Label: label}
+			if seen[edge] {
+				continue
+			}
+
+			seen[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+
+			// dangling links still get drawn
+			if _, exists := known[link.Target]; !exists {
+				known[link.Target] = nil
+				graph.Nodes = append(graph.Nodes, render.GraphNode{
+					ID:       nodeID(link.Target),
+					Label:    utils.PadValue(link.Target, globals.NumericPadWidth),
+					Status:   "",
+					Color:    "",
+					Terminal: false,
+				})
+			}
+		}
+	}
+
+	return graph
+}
+
+// edgeDirection returns the forward direction and label for a link held by source.
+// Returns false if the link should be skipped, as its forward twin on the target draws the same edge.
+func edgeDirection(source *ADR, link Link, known map[int]*ADR) (int, int, string, bool) {
+	for _, linkType := range linkTypes {
+		switch {
+		// symmetric: drawn once, from the older ADR
+		case linkType.ForwardName() == linkType.ReverseName() && link.Type == linkType.ForwardName():
+			from, to := min(source.Sequence, link.Target), max(source.Sequence, link.Target)
+
+			return from, to, linkType.Forward, true
+
+		case link.Type == linkType.ForwardName():
+			return source.Sequence, link.Target, linkType.Forward, true
+
+		// reverse links point the other way
+		case link.Type == linkType.ReverseName():
+			return link.Target, source.Sequence, linkType.Forward, true
+		}
+	}
+
+	// custom types. when both ADRs hold their half, the older ADR's half is skipped. custom links don't name their
+	// twin's type, so the halves are paired up in order: only as many links are skipped as the target holds back.
+	target := known[link.Target]
+	if target != nil && source.Sequence < target.Sequence &&
+		slices.Index(customLinks(source.Links, link.Target), link) < len(customLinks(target.Links, source.Sequence)) {
+		return 0, 0, "", false
+	}
+
+	return source.Sequence, link.Target, link.DisplayLabel(), true
+}
+
+// customLinks returns the links of custom types pointing at target, in order.
+func customLinks(links []Link, target int) []Link {
+	var custom []Link
+
+	for _, link := range links {
+		builtIn := slices.ContainsFunc(linkTypes, func(lt LinkType) bool {
+			return link.Type == lt.ForwardName() || link.Type == lt.ReverseName()
+		})
+
+		if link.Target == target && !builtIn {
+			custom = append(custom, link)
+		}
+	}
+
+	return custom
+}
+
+// nodeID is the graph node id for an ADR sequence number.
+func nodeID(sequence int) string {
+	return utils.PadValue(sequence, globals.NumericPadWidth)
+}
@@ -0,0 +1,90 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+func TestBuildGraph(t *testing.T) {
+	files := []File{
+		{ADR: &ADR{
+			Sequence: 1, Title: "Old", Status: "superseded by [0002](0002-new.md)",
+			Links: []Link{{Type: "superseded-by", Target: 2}, {Type: LinkTypeRelatesTo, Target: 2}},
+		}},
+		{ADR: &ADR{
+			Sequence: 2, Title: "New", Status: StatusAccepted,
+			Links: []Link{
+				{Type: LinkTypeSupersedes, Target: 1},
+				{Type: LinkTypeRelatesTo, Target: 1},
+				{Type: "extends", Target: 1, Label: "Extends"},
+				{Type: LinkTypeDependsOn, Target: 9},
+			},
+		}},
+	}
+	// the older half of the custom link
+	files[0].Links = append(files[0].Links, Link{Type: "extended-by", Target: 2, Label: "Extended by"})
+
+	graph := BuildGraph(files, DefaultLifecycle())
+
+	assert.Equal(t, []render.GraphNode{
+		{ID: "0001", Label: "0001: Old", Status: StatusSuperseded, Color: "#6e7781", Terminal: true},
+		{ID: "0002", Label: "0002: New", Status: StatusAccepted, Color: "#1a7f37", Terminal: false},
+		{ID: "0009", Label: "0009", Status: "", Color: "", Terminal: false},
+	}, graph.Nodes)
+
+	assert.Equal(t, []render.GraphEdge{
+		{From: "0002", To: "0001", Label: "Supersedes"},
+		{From: "0001", To: "0002", Label: "Relates to"},
+		{From: "0002", To: "0001", Label: "Extends"},
+		{From: "0002", To: "0009", Label: "Depends on"},
+	}, graph.Edges)
+}
+
+func TestBuildGraph_CustomLinks(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		older      []Link
+		newer      []Link
+		assertFunc func(t *testing.T, edges []render.GraphEdge)
+	}{
+		{
+			name:  "built-in links back don't hide a custom link",
+			older: []Link{{Type: "blocks", Target: 2, Label: "Blocks"}, {Type: LinkTypeRelatesTo, Target: 2}},
+			newer: []Link{{Type: LinkTypeRelatesTo, Target: 1}},
+			assertFunc: func(t *testing.T, edges []render.GraphEdge) {
+				assert.Equal(t, []render.GraphEdge{
+					{From: "0001", To: "0002", Label: "Blocks"},
+					{From: "0001", To: "0002", Label: "Relates to"},
+				}, edges)
+			},
+		},
+		{
+			name: "each custom half is paired with one held back",
+			older: []Link{
+				{Type: "extended-by", Target: 2, Label: "Extended by"},
+				{Type: "blocks", Target: 2, Label: "Blocks"},
+			},
+			newer: []Link{{Type: "extends", Target: 1, Label: "Extends"}},
+			assertFunc: func(t *testing.T, edges []render.GraphEdge) {
+				assert.Equal(t, []render.GraphEdge{
+					{From: "0001", To: "0002", Label: "Blocks"},
+					{From: "0002", To: "0001", Label: "Extends"},
+				}, edges)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []File{
+				{ADR: &ADR{Sequence: 1, Title: "Older", Status: StatusAccepted, Links: tt.older}},
+				{ADR: &ADR{Sequence: 2, Title: "Newer", Status: StatusAccepted, Links: tt.newer}},
+			}
+
+			tt.assertFunc(t, BuildGraph(files, DefaultLifecycle()).Edges)
+		})
+	}
+}
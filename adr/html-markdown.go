@@ -0,0 +1,422 @@
+package adr
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlBlockElements are the html elements rendered as markdown blocks. anything else is inline.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var htmlBlockElements = []string{
+	"address", "article", "aside", "blockquote", "dd", "details", "div", "dl", "dt", "figcaption", "figure",
+	"footer", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "p", "pre",
+	"section", "table", "ul",
+}
+
+// htmlDroppedElements never hold ADR content. they're dropped with everything inside them.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var htmlDroppedElements = []string{"head", "iframe", "noscript", "object", "script", "style", "template"}
+
+// htmlSpacePattern matches runs of html whitespace, which render as a single space.
+var htmlSpacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+// entityPattern matches text markdown would read as a character reference, eg: "&lt;".
+var entityPattern = regexp.MustCompile(`&(#[0-9]+|#[xX][0-9a-fA-F]+|[A-Za-z][A-Za-z0-9]*);`)
+
+// autolinkPattern matches text markdown would read as an autolink, eg: "<https://example.com>".
+// any other "<" is left alone: raw html in markdown renders as escaped text, just as it reads.
+var autolinkPattern = regexp.MustCompile(
+	"<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\\s<>]*|[A-Za-z0-9.!#$%&'*+/=?^_`{|}~-]+@[A-Za-z0-9.-]+)>",
+)
+
+// blockStartPattern matches paragraph starts markdown would read as a heading, list, quote, rule, or html block.
+var blockStartPattern = regexp.MustCompile(`^([-+=#><]|[0-9]+[.)])`)
+
+// htmlMarkdown converts an html section back to the markdown it's rendered from, so it can be rendered again.
+// html is read as html documents render it: unknown elements keep their text, and scripts and styles are dropped.
+func htmlMarkdown(fragment string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+
+	return strings.Join(markdownBlocks(nodes), "\n\n")
+}
+
+// markdownBlocks renders nodes as markdown blocks. runs of inline nodes become a paragraph.
+func markdownBlocks(nodes []*html.Node) []string {
+	var (
+		blocks    []string
+		paragraph strings.Builder
+	)
+
+	flush := func() {
+		// lines after a break keep no leading space, which markdown would read as indentation
+		text := strings.ReplaceAll(strings.TrimSpace(paragraph.String()), "\\\n ", "\\\n")
+		paragraph.Reset()
+
+		if text == "" {
+			return
+		}
+
+		// keep text from reading as a block marker, eg: "1. not a list"
+		if match := blockStartPattern.FindStringIndex(text); match != nil {
+			text = text[:match[1]-1] + `\` + text[match[1]-1:]
+		}
+
+		blocks = append(blocks, text)
+	}
+
+	for _, node := range nodes {
+		if node.Type == html.ElementNode && slices.Contains(htmlBlockElements, node.Data) {
+			flush()
+
+			if block := markdownBlock(node); block != "" {
+				blocks = append(blocks, block)
+			}
+
+			continue
+		}
+
+		paragraph.WriteString(markdownInline(node))
+	}
+
+	flush()
+
+	return blocks
+}
+
+// markdownBlock renders a block element as markdown.
+func markdownBlock(node *html.Node) string {
+	switch node.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(node.Data[1:])
+
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(markdownInlineChildren(node))
+	case "ul", "ol":
+		return markdownList(node)
+	case "pre":
+		return markdownCodeBlock(node)
+	case "blockquote":
+		return prefixLines(strings.Join(markdownBlocks(children(node)), "\n\n"), "> ", ">")
+	case "hr":
+		return "---"
+	case "table":
+		return markdownTable(node)
+	default:
+		return strings.Join(markdownBlocks(children(node)), "\n\n")
+	}
+}
+
+// markdownList renders a list, indenting each item's continuation lines under its marker.
+// items holding paragraphs make a loose list, with blank lines between items.
+func markdownList(list *html.Node) string {
+	number, _ := strconv.Atoi(attribute(list, "start"))
+	if number == 0 {
+		number = 1
+	}
+
+	var (
+		items []string
+		loose bool
+	)
+
+	for _, item := range children(list) {
+		if item.Type != html.ElementNode || item.Data != "li" {
+			continue
+		}
+
+		marker := "- "
+		if list.Data == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		separator := "\n"
+		if slices.ContainsFunc(children(item), func(n *html.Node) bool { return n.Data == "p" }) {
+			separator, loose = "\n\n", true
+		}
+
+		content := strings.Join(markdownBlocks(children(item)), separator)
+		items = append(items, marker+prefixLines(content, strings.Repeat(" ", len(marker)), "")[len(marker):])
+	}
+
+	if loose {
+		return strings.Join(items, "\n\n")
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// markdownCodeBlock renders a preformatted block as a fenced code block, keeping the language of its code element.
+func markdownCodeBlock(pre *html.Node) string {
+	language := ""
+	if code := firstChild(pre, "code"); code != nil {
+		for _, class := range strings.Fields(attribute(code, "class")) {
+			if strings.HasPrefix(class, "language-") {
+				language = strings.TrimPrefix(class, "language-")
+			}
+		}
+	}
+
+	content := strings.TrimSuffix(textContent(pre), "\n")
+	fence := strings.Repeat("`", max(3, longestRun(content, '`')+1)) //nolint:mnd // markdown's shortest fence
+
+	return fence + language + "\n" + content + "\n" + fence
+}
+
+// markdownTable renders a table as a gfm table. its first row is the header.
+func markdownTable(table *html.Node) string {
+	var rows []string
+
+	walk(table, func(node *html.Node) bool {
+		if node.Type != html.ElementNode || node.Data != "tr" {
+			return true
+		}
+
+		var cells []string
+
+		for _, cell := range children(node) {
+			if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+				cells = append(cells, strings.ReplaceAll(strings.TrimSpace(markdownInlineChildren(cell)), "|", `\|`))
+			}
+		}
+
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+
+		if len(rows) == 1 {
+			rows = append(rows, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+
+		return false
+	})
+
+	return strings.Join(rows, "\n")
+}
+
+// markdownInline renders an inline node as markdown.
+func markdownInline(node *html.Node) string {
+	switch node.Type {
+	case html.TextNode:
+		return escapeMarkdown(htmlSpacePattern.ReplaceAllString(node.Data, " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	if slices.Contains(htmlDroppedElements, node.Data) {
+		return ""
+	}
+
+	inner := markdownInlineChildren(node)
+
+	switch node.Data {
+	case "em", "i":
+		return wrapInline(inner, "*")
+	case "strong", "b":
+		return wrapInline(inner, "**")
+	case "del", "s", "strike":
+		return wrapInline(inner, "~~")
+	case "code":
+		return markdownCodeSpan(textContent(node))
+	case "a":
+		href := attribute(node, "href")
+		if href == "" {
+			return inner
+		}
+
+		return "[" + inner + "](" + markdownDestination(href) + ")"
+	case "img":
+		return "![" + escapeMarkdown(attribute(node, "alt")) + "](" + markdownDestination(attribute(node, "src")) + ")"
+	case "br":
+		return "\\\n"
+	case "input":
+		// gfm task list items
+		if attribute(node, "type") != "checkbox" {
+			return ""
+		}
+
+		if hasAttribute(node, "checked") {
+			return "[x] "
+		}
+
+		return "[ ] "
+	default:
+		return inner
+	}
+}
+
+// markdownInlineChildren renders a node's children as inline markdown.
+func markdownInlineChildren(node *html.Node) string {
+	var builder strings.Builder
+	for _, child := range children(node) {
+		builder.WriteString(markdownInline(child))
+	}
+
+	return builder.String()
+}
+
+// wrapInline wraps inline markdown in a delimiter, eg: "*" for emphasis.
+// markdown delimiters can't sit against whitespace, so it's moved outside them.
+func wrapInline(inner, delimiter string) string {
+	trimmed := strings.TrimSpace(inner)
+	if trimmed == "" {
+		return inner
+	}
+
+	leading := inner[:len(inner)-len(strings.TrimLeft(inner, " "))]
+	trailing := inner[len(strings.TrimRight(inner, " ")):]
+
+	return leading + delimiter + trimmed + delimiter + trailing
+}
+
+// markdownCodeSpan renders code as a code span, fenced by more backticks than it holds.
+func markdownCodeSpan(code string) string {
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+
+	return fence + code + fence
+}
+
+// markdownDestination renders a link destination, bracketed if it holds characters a bare destination can't.
+func markdownDestination(destination string) string {
+	if strings.ContainsAny(destination, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(destination) + ">"
+	}
+
+	return destination
+}
+
+// escapeMarkdown escapes text so markdown reads it literally. underscores inside words are left alone, since
+// markdown doesn't read them as emphasis, eg: "snake_case".
+func escapeMarkdown(text string) string {
+	var builder strings.Builder
+
+	for i, char := range text {
+		switch char {
+		case '\\', '`', '*', '[', ']', '~', '|':
+			builder.WriteByte('\\')
+		case '_':
+			if i == 0 || i == len(text)-1 || !isWordByte(text[i-1]) || !isWordByte(text[i+1]) {
+				builder.WriteByte('\\')
+			}
+		}
+
+		builder.WriteRune(char)
+	}
+
+	escaped := entityPattern.ReplaceAllString(builder.String(), `\$0`)
+
+	return autolinkPattern.ReplaceAllString(escaped, `\$0`)
+}
+
+// isWordByte reports whether b is an ascii letter or digit.
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// prefixLines prefixes every line of text. empty lines get emptyPrefix instead.
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// longestRun returns the length of the longest run of char in text.
+func longestRun(text string, char rune) int {
+	longest, current := 0, 0
+
+	for _, c := range text {
+		if c != char {
+			current = 0
+
+			continue
+		}
+
+		current++
+		longest = max(longest, current)
+	}
+
+	return longest
+}
+
+// children returns a node's child nodes.
+func children(node *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, child)
+	}
+
+	return nodes
+}
+
+// firstChild returns the node's first child element named tag, or nil.
+func firstChild(node *html.Node, tag string) *html.Node {
+	for _, child := range children(node) {
+		if child.Type == html.ElementNode && child.Data == tag {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// walk visits node and its descendants depth-first. visit returns false to skip a node's descendants.
+func walk(node *html.Node, visit func(*html.Node) bool) {
+	if !visit(node) {
+		return
+	}
+
+	for _, child := range children(node) {
+		walk(child, visit)
+	}
+}
+
+// textContent returns the text under node, as written.
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+
+	walk(node, func(n *html.Node) bool {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+		}
+
+		return true
+	})
+
+	return builder.String()
+}
+
+// attribute returns the value of a node's attribute, or an empty string.
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+// hasAttribute reports whether a node has an attribute, even an empty one.
+func hasAttribute(node *html.Node, name string) bool {
+	return slices.ContainsFunc(node.Attr, func(attr html.Attribute) bool { return attr.Key == name })
+}
@@ -0,0 +1,105 @@
+package adr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+// TestHTMLMarkdown guarantees html sections read back as markdown that renders to the same html.
+func TestHTMLMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		// want is the expected markdown, when it's expected to differ from the original
+		want string
+	}{
+		{name: "paragraphs", markdown: "one\n\ntwo"},
+		{name: "emphasis, code and links", markdown: "the *intranet* takes **html**, `<b>` and [docs](https://example.com/a_b)"},
+		{name: "nested list", markdown: "- one\n  - nested\n- two"},
+		{name: "loose list", markdown: "- one\n\n  more\n\n- two"},
+		{name: "ordered list", markdown: "3. three\n4. four"},
+		{name: "fenced code with a language", markdown: "```go\nfunc main() {\n\t// `quoted`\n}\n```"},
+		{name: "blockquote", markdown: "> quoted\n>\n> twice"},
+		{name: "hard break", markdown: "line\\\nbreak"},
+		{name: "escaped syntax", markdown: `not \*emphasis\*, not \[a link\], not \&amp;`},
+		{name: "html text", markdown: "use Result<T> and <Option> types"},
+		{name: "html text starting a paragraph", markdown: `\<script>alert(1)</script>`},
+		{name: "autolink text", markdown: `not \<https://example.com> a link`},
+		{name: "snake case", markdown: "snake_case stays, \\_leading\\_ is escaped"},
+		{name: "table", markdown: "| a | b |\n| --- | --- |\n| 1 | 2 |"},
+		{name: "heading", markdown: "### Detail\n\nmore"},
+		{name: "paragraph that looks like a list", markdown: `1\. not a list`},
+		{name: "strikethrough", markdown: "~~gone~~"},
+		{
+			name:     "raw html is kept as text",
+			markdown: "kept\n\n<script>alert(1)</script>",
+			want:     "kept\n\n\\<script>alert(1)</script>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := render.MarkdownHTML(tt.markdown)
+			require.NoError(t, err)
+
+			want := tt.want
+			if want == "" {
+				want = tt.markdown
+			}
+
+			markdown := htmlMarkdown(rendered)
+			assert.Equal(t, want, markdown)
+
+			rerendered, err := render.MarkdownHTML(markdown)
+			require.NoError(t, err)
+			assert.Equal(t, rendered, rerendered)
+		})
+	}
+}
+
+// TestParse_ReRenderHTML guarantees a parsed html ADR renders again unchanged, as it does when it's superseded.
+func TestParse_ReRenderHTML(t *testing.T) {
+	htmlTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatHTML)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     15,
+		Title:        "Publish HTML",
+		Context:      "the *intranet* takes html:\n\n- `<b>` tags\n- [links](https://example.com)",
+		Decision:     "render **html**",
+		Status:       "proposed",
+		Consequences: "```sh\nmake html\n```",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	doc, err := record.BuildDocument(htmlTemplate)
+	require.NoError(t, err)
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record.Context, parsed.Context)
+	assert.Equal(t, record.Decision, parsed.Decision)
+	assert.Equal(t, record.Consequences, parsed.Consequences)
+
+	// the superseding ADR starts from the superseded one's context
+	successor := &ADR{
+		Sequence:     16,
+		Title:        "Publish Everything",
+		Context:      parsed.Context,
+		Decision:     parsed.Decision,
+		Status:       "proposed",
+		Consequences: parsed.Consequences,
+		Created:      record.Created,
+	}
+
+	rerendered, err := successor.BuildDocument(htmlTemplate)
+	require.NoError(t, err)
+	assert.Contains(t, string(rerendered.Content), "<li><code>&lt;b&gt;</code> tags</li>")
+	assert.Contains(t, string(rerendered.Content), "<p>render <strong>html</strong></p>")
+	assert.NotContains(t, string(rerendered.Content), "raw HTML omitted")
+}
@@ -0,0 +1,68 @@
+package adr
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/render"
+)
+
+// htmlTitlePattern matches the document title, the article's first-level heading.
+var htmlTitlePattern = regexp.MustCompile(`(?s)<h1[^>]*>(.*?)</h1>`)
+
+// htmlArticle splits html content around the inner content of its `<article>` element, which holds the ADR.
+// everything outside it, like the head and its styles, is left alone. documents without an article are all content.
+func htmlArticle(content []byte) ([]byte, []byte, []byte) {
+	open := bytes.Index(content, []byte("<article"))
+	closing := bytes.LastIndex(content, []byte("</article>"))
+
+	if open < 0 || closing < open {
+		return nil, content, nil
+	}
+
+	start := open + bytes.IndexByte(content[open:], '>') + 1
+
+	return content[:start], content[start:closing], content[closing:]
+}
+
+// HTMLArticle returns the inner content of an html ADR's `<article>` element, which holds the ADR.
+// documents without an article are all content.
+func HTMLArticle(content []byte) []byte {
+	_, article, _ := htmlArticle(content)
+
+	return article
+}
+
+// scanHTML splits html content into its title and sections: the article's `<h1>` and `<h2>` headings.
+// section values are html. Parse reads them back as markdown.
+func scanHTML(content []byte) parsedDocument {
+	_, article, _ := htmlArticle(content)
+
+	doc := scanSections(article, markups[render.DocumentFormatHTML])
+
+	// scanSections takes the first line as the title. in html, that's markup.
+	doc.title = ""
+	if matches := htmlTitlePattern.FindSubmatch(article); matches != nil {
+		doc.title = html.UnescapeString(strings.TrimSpace(string(matches[1])))
+	}
+
+	return doc
+}
+
+// replaceHTMLSection replaces the content of the html section matching key with value, or appends the section if
+// it's missing and appendMissing is set. only the article is changed.
+// Returns the content and whether the section was found or appended. see replaceSection.
+func replaceHTMLSection(content []byte, key, heading, value string, appendMissing bool) ([]byte, bool) {
+	syntax := markups[render.DocumentFormatHTML]
+	before, article, after := htmlArticle(content)
+
+	rewritten, found := replaceSection(article, syntax, key, value)
+	if !found && appendMissing {
+		rewritten, found = append(appendSection(article, syntax, heading, value), '\n'), true
+	}
+
+	return slices.Concat(before, rewritten, after), found
+}
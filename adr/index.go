@@ -0,0 +1,90 @@
+package adr
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// indexHeading opens a brand-new index file.
+const indexHeading = "# Architecture Decision Records"
+
+// IndexMarkdown renders the generated index block: a markdown table of every file's number, linked title, status,
+// and date, wrapped in marker comments. links are relative to indexDir, where the index file lives.
+func IndexMarkdown(files []File, lifecycle *Lifecycle, indexDir string) string {
+	var builder strings.Builder
+
+	builder.WriteString(globals.IndexStartMarker + "\n")
+	builder.WriteString("<!-- generated by `adr-er index`. changes between these markers will be overwritten -->\n\n")
+	builder.WriteString("| Number | Title | Status | Date |\n")
+	builder.WriteString("| ------ | ----- | ------ | ---- |\n")
+
+	for _, file := range files {
+		target, err := filepath.Rel(indexDir, file.Path)
+		if err != nil {
+			target = file.Path
+		}
+
+		fmt.Fprintf(&builder, "| %s | [%s](%s) | %s | %s |\n",
+			utils.PadValue(file.Sequence, globals.NumericPadWidth),
+			escapeTableCell(file.Title),
+			filepath.ToSlash(target),
+			escapeTableCell(lifecycle.Label(file.Status)),
+			formatDate(file.Created),
+		)
+	}
+
+	builder.WriteString("\n" + globals.IndexEndMarker + "\n")
+
+	return builder.String()
+}
+
+// UpdateIndex places the generated index block in existing, returning the new content.
+// if existing already holds a generated block, only that block is replaced. otherwise, the block is appended,
+// and an empty file gets a heading first.
+func UpdateIndex(existing []byte, block string) ([]byte, error) {
+	start := bytes.Index(existing, []byte(globals.IndexStartMarker))
+	end := bytes.Index(existing, []byte(globals.IndexEndMarker))
+
+	switch {
+	// nothing yet
+	case len(bytes.TrimSpace(existing)) == 0:
+		return []byte(indexHeading + "\n\n" + block), nil
+
+	// hand-written file, without a generated block
+	case start < 0 && end < 0:
+		updated := bytes.TrimRight(existing, "\r\n")
+
+		return append(updated, []byte("\n\n"+block)...), nil
+
+	// a single, well-formed block
+	case start >= 0 && end > start:
+		// the block owns the end marker's line ending
+		end += len(globals.IndexEndMarker)
+		if end < len(existing) && existing[end] == '\r' {
+			end++
+		}
+
+		if end < len(existing) && existing[end] == '\n' {
+			end++
+		}
+
+		updated := make([]byte, 0, len(existing)+len(block))
+		updated = append(updated, existing[:start]...)
+		updated = append(updated, block...)
+
+		return append(updated, existing[end:]...), nil
+
+	default:
+		return nil, globals.ValidationError("index", "generated block markers are missing or out of order")
+	}
+}
+
+// escapeTableCell keeps a value from breaking out of its markdown table cell.
+func escapeTableCell(value string) string {
+	return strings.ReplaceAll(strings.TrimSpace(value), "|", `\|`)
+}
@@ -0,0 +1,87 @@
+package adr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestIndexMarkdown(t *testing.T) {
+	files := []File{
+		{
+			ADR:  &ADR{Sequence: 1, Title: "Pipes | Filters", Status: "Accepted", Created: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			Path: "/repo/adr/0001-pipes-filters.md",
+		},
+		{
+			ADR:  &ADR{Sequence: 2, Title: "Replace it", Status: "superseded by [0003](0003-x.md)"},
+			Path: "/repo/adr/0002-replace-it.md",
+		},
+	}
+
+	assert.Equal(t,
+		"<!-- adr-er:index:start -->\n"+
+			"<!-- generated by `adr-er index`. changes between these markers will be overwritten -->\n\n"+
+			"| Number | Title | Status | Date |\n"+
+			"| ------ | ----- | ------ | ---- |\n"+
+			"| 0001 | [Pipes \\| Filters](0001-pipes-filters.md) | accepted | 2024-03-01 |\n"+
+			"| 0002 | [Replace it](0002-replace-it.md) | superseded |  |\n"+
+			"\n<!-- adr-er:index:end -->\n",
+		IndexMarkdown(files, DefaultLifecycle(), "/repo/adr"),
+	)
+}
+
+func TestUpdateIndex(t *testing.T) {
+	block := "<!-- adr-er:index:start -->\nnew\n<!-- adr-er:index:end -->\n"
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		existing   string
+		assertFunc func(t *testing.T, updated []byte, err error)
+	}{
+		{
+			name:     "new file",
+			existing: "",
+			assertFunc: func(t *testing.T, updated []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "# Architecture Decision Records\n\n"+block, string(updated))
+			},
+		},
+		{
+			name:     "hand-written file",
+			existing: "# Decisions\n\nwritten by hand\n\n",
+			assertFunc: func(t *testing.T, updated []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "# Decisions\n\nwritten by hand\n\n"+block, string(updated))
+			},
+		},
+		{
+			name:     "replaces only the block",
+			existing: "intro\n<!-- adr-er:index:start -->\nold\n<!-- adr-er:index:end -->\noutro\n",
+			assertFunc: func(t *testing.T, updated []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "intro\n"+block+"outro\n", string(updated))
+			},
+		},
+		{
+			name:     "broken markers",
+			existing: "intro\n<!-- adr-er:index:end -->\n<!-- adr-er:index:start -->\n",
+			assertFunc: func(t *testing.T, updated []byte, err error) {
+				assert.Nil(t, updated)
+
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updated, err := UpdateIndex([]byte(test.existing), block)
+			test.assertFunc(t, updated, err)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package adr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+// Status values for the default ADR lifecycle.
+const (
+	StatusProposed   = "proposed"
+	StatusAccepted   = "accepted"
+	StatusRejected   = "rejected"
+	StatusDeprecated = "deprecated"
+	StatusSuperseded = "superseded"
+)
+
+// legacySupersededSpelling is how older documents spell superseded. it's an alias of a lifecycle's superseded status.
+const legacySupersededSpelling = "superceded"
+
+// StatusDefinition describes how a status is displayed and treated, beyond its place in the lifecycle.
+type StatusDefinition struct {
+	// Name is the status, as stored in documents
+	Name string
+	// Color displays the status, as a hex color (eg: "#1a7f37") or an ANSI color number (eg: "212"). may be empty.
+	Color string
+	// Terminal statuses are final: ADRs never move out of them
+	Terminal bool
+	// Active statuses are in effect, eg: accepted
+	Active bool
+}
+
+// StatusChange records a single status transition in an ADR's history.
+type StatusChange struct {
+	// From is the status before the transition. it's empty for the initial status.
+	From string
+	// To is the status after the transition
+	To string
+	// At is when the transition happened
+	At time.Time
+}
+
+// Lifecycle defines the statuses an ADR can hold and the legal transitions between them.
+// Status values are compared case-insensitively.
+type Lifecycle struct {
+	// statuses holds every known status, in display order
+	statuses []string
+	// initial holds the statuses a new ADR may start with
+	initial []string
+	// transitions maps a status to the statuses it may move to
+	transitions map[string][]string
+	// definitions hold each status's display color and flags
+	definitions map[string]StatusDefinition
+	// aliases map other names, like misspellings, onto known statuses
+	aliases map[string]string
+	// superseded is the status superseded ADRs move to. empty if there's none.
+	superseded string
+}
+
+// NewLifecycle is a constructor. statuses lists every known status in display order, initial lists the statuses
+// a new ADR may start with, and transitions maps each status to the statuses it may move to.
+// "superseded", if it's one of the statuses, is where superseded ADRs move to. see WithSuperseded.
+func NewLifecycle(statuses, initial []string, transitions map[string][]string) *Lifecycle {
+	lifecycle := &Lifecycle{
+		statuses:    statuses,
+		initial:     initial,
+		transitions: transitions,
+		definitions: make(map[string]StatusDefinition),
+		aliases:     make(map[string]string),
+		superseded:  "",
+	}
+
+	return lifecycle.WithSuperseded(StatusSuperseded)
+}
+
+// WithDefinitions sets the display color and flags of statuses, returning the lifecycle for chaining.
+// definitions for unknown statuses are ignored.
+func (l *Lifecycle) WithDefinitions(definitions ...StatusDefinition) *Lifecycle {
+	for _, definition := range definitions {
+		if slices.Contains(l.statuses, definition.Name) {
+			l.definitions[definition.Name] = definition
+		}
+	}
+
+	return l
+}
+
+// WithAliases recognizes other names for known statuses, returning the lifecycle for chaining, eg: "retired" for
+// "deprecated". aliases of unknown statuses are ignored.
+func (l *Lifecycle) WithAliases(aliases map[string]string) *Lifecycle {
+	for alias, status := range aliases {
+		if slices.Contains(l.statuses, status) {
+			l.aliases[strings.ToLower(strings.TrimSpace(alias))] = status
+		}
+	}
+
+	return l
+}
+
+// WithSuperseded sets the status superseded ADRs move to, returning the lifecycle for chaining, eg: "retired".
+// the legacy spelling "superceded" becomes an alias of it, unless it's a status or alias already.
+// unknown statuses are ignored.
+func (l *Lifecycle) WithSuperseded(status string) *Lifecycle {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if !slices.Contains(l.statuses, status) {
+		return l
+	}
+
+	if alias, aliased := l.aliases[legacySupersededSpelling]; !aliased || alias == l.superseded {
+		if !slices.Contains(l.statuses, legacySupersededSpelling) {
+			l.aliases[legacySupersededSpelling] = status
+		}
+	}
+
+	l.superseded = status
+
+	return l
+}
+
+// DefaultLifecycle returns the standard Nygard lifecycle:
+// proposals are accepted or rejected, accepted decisions are eventually deprecated or superseded,
+// and rejected proposals may be proposed again. accepted decisions are active, and superseded ones are final.
+func DefaultLifecycle() *Lifecycle {
+	return NewLifecycle(
+		[]string{StatusProposed, StatusAccepted, StatusRejected, StatusDeprecated, StatusSuperseded},
+		[]string{StatusProposed, StatusAccepted, StatusRejected},
+		map[string][]string{
+			StatusProposed:   {StatusAccepted, StatusRejected},
+			StatusAccepted:   {StatusDeprecated, StatusSuperseded},
+			StatusRejected:   {StatusProposed},
+			StatusDeprecated: {StatusSuperseded},
+			StatusSuperseded: {},
+		},
+	).WithDefinitions(
+		StatusDefinition{Name: StatusProposed, Color: "#bf8700", Terminal: false, Active: false},
+		StatusDefinition{Name: StatusAccepted, Color: "#1a7f37", Terminal: false, Active: true},
+		StatusDefinition{Name: StatusRejected, Color: "#cf222e", Terminal: false, Active: false},
+		StatusDefinition{Name: StatusDeprecated, Color: "#6e7781", Terminal: false, Active: false},
+		StatusDefinition{Name: StatusSuperseded, Color: "#6e7781", Terminal: true, Active: false},
+	)
+}
+
+// Statuses returns every known status, in display order.
+func (l *Lifecycle) Statuses() []string { return slices.Clone(l.statuses) }
+
+// InitialStatuses returns the statuses a new ADR may start with.
+func (l *Lifecycle) InitialStatuses() []string { return slices.Clone(l.initial) }
+
+// Superseded returns the status superseded ADRs move to, and whether the lifecycle has one.
+func (l *Lifecycle) Superseded() (string, bool) { return l.superseded, l.superseded != "" }
+
+// Next returns the statuses that status may legally move to. terminal statuses have none.
+func (l *Lifecycle) Next(status string) []string {
+	normalized, ok := l.Normalize(status)
+	if !ok || l.IsTerminal(normalized) {
+		return nil
+	}
+
+	return slices.Clone(l.transitions[normalized])
+}
+
+// Normalize maps a raw status value, as found in a document, to its known status.
+// matching is case-insensitive, and trailing detail is ignored: "Superseded by [0019](...)" normalizes to "superseded".
+// aliases normalize to the status they stand for: "superceded" normalizes to "superseded".
+// Returns false if the value doesn't start with a known status.
+func (l *Lifecycle) Normalize(raw string) (string, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+
+	// prefer the longest match, so multi-word statuses aren't shadowed by shorter ones
+	match, matchLength := "", 0
+
+	consider := func(name, status string) {
+		if raw != name && !strings.HasPrefix(raw, name+" ") {
+			return
+		}
+
+		if len(name) > matchLength {
+			match, matchLength = status, len(name)
+		}
+	}
+
+	for _, status := range l.statuses {
+		consider(status, status)
+	}
+
+	for alias, status := range l.aliases {
+		consider(alias, status)
+	}
+
+	return match, match != ""
+}
+
+// Lookup maps a status name, as typed, to its known status. unlike Normalize, there's no trailing detail: only a
+// status or alias, in any case, matches. "Accepted" looks up as "accepted", but "accepted for now" doesn't.
+func (l *Lifecycle) Lookup(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if slices.Contains(l.statuses, name) {
+		return name, true
+	}
+
+	status, ok := l.aliases[name]
+
+	return status, ok
+}
+
+// Definition returns the definition of a raw status value's known status, and whether it has one.
+func (l *Lifecycle) Definition(raw string) (StatusDefinition, bool) {
+	normalized, ok := l.Normalize(raw)
+	if !ok {
+		return StatusDefinition{}, false //nolint:exhaustruct // zero value
+	}
+
+	definition, ok := l.definitions[normalized]
+
+	return definition, ok
+}
+
+// Color returns the display color of a raw status value, or an empty string if it has none.
+func (l *Lifecycle) Color(raw string) string {
+	definition, _ := l.Definition(raw)
+
+	return definition.Color
+}
+
+// IsTerminal reports whether a raw status value is final. ADRs never move out of terminal statuses.
+func (l *Lifecycle) IsTerminal(raw string) bool {
+	definition, _ := l.Definition(raw)
+
+	return definition.Terminal
+}
+
+// IsActive reports whether a raw status value is in effect.
+func (l *Lifecycle) IsActive(raw string) bool {
+	definition, _ := l.Definition(raw)
+
+	return definition.Active
+}
+
+// Label returns the known status for a raw status value, dropping any trailing detail.
+// unknown values are returned trimmed, but otherwise as-is.
+func (l *Lifecycle) Label(raw string) string {
+	if normalized, ok := l.Normalize(raw); ok {
+		return normalized
+	}
+
+	return strings.TrimSpace(raw)
+}
+
+// CheckTransition validates a status change, returning nil if it's legal.
+// from is read as found in a document, and may carry trailing detail. to must be a status or alias, as Lookup reads it.
+// an empty from status is treated as a brand-new ADR, which must start with one of the initial statuses.
+// Returns an InputValidationError for unknown statuses and a StatusTransitionError for illegal transitions.
+func (l *Lifecycle) CheckTransition(from, to string) error {
+	target, ok := l.Lookup(to)
+	if !ok {
+		return globals.ValidationError("status", fmt.Sprintf("unknown status %q", to))
+	}
+
+	// new ADRs
+	if strings.TrimSpace(from) == "" {
+		if !slices.Contains(l.initial, target) {
+			return globals.StatusTransitionError{From: "", To: target}
+		}
+
+		return nil
+	}
+
+	source, ok := l.Normalize(from)
+	if !ok {
+		return globals.ValidationError("status", fmt.Sprintf("unknown current status %q", from))
+	}
+
+	if l.IsTerminal(source) || !slices.Contains(l.transitions[source], target) {
+		return globals.StatusTransitionError{From: source, To: target}
+	}
+
+	return nil
+}
+
+// TransitionStatus moves the ADR to status `to`, enforcing the lifecycle's rules.
+// On success, the status, status change date, and status history are all updated.
+// the known status is stored, so aliases and other spellings of it are written as the status they stand for.
+func (adr *ADR) TransitionStatus(lifecycle *Lifecycle, to string, at time.Time) error {
+	if err := lifecycle.CheckTransition(adr.Status, to); err != nil {
+		return err
+	}
+
+	// history records the normalized statuses
+	from, _ := lifecycle.Normalize(adr.Status)
+	target, _ := lifecycle.Lookup(to)
+
+	adr.Status = target
+	adr.StatusChanged = at
+	adr.StatusHistory = append(adr.StatusHistory, StatusChange{From: from, To: target, At: at})
+
+	return nil
+}
+
+// Supersede moves the ADR to the lifecycle's superseded status, pointing its status at replacement, which lives at
+// replacementPath relative to this ADR. eg: "superseded by [0019: New Decision](0019-new-decision.md)".
+func (adr *ADR) Supersede(lifecycle *Lifecycle, replacement *ADR, replacementPath string, at time.Time) error {
+	status, ok := lifecycle.Superseded()
+	if !ok {
+		return globals.ValidationError("status", "the lifecycle has no status for superseded ADRs")
+	}
+
+	if err := adr.TransitionStatus(lifecycle, status, at); err != nil {
+		return err
+	}
+
+	adr.Status = SupersededByStatus(status, replacement, replacementPath)
+
+	return nil
+}
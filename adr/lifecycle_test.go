@@ -0,0 +1,189 @@
+package adr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestLifecycle_Normalize(t *testing.T) {
+	lifecycle := DefaultLifecycle()
+
+	tests := []struct {
+		raw    string
+		want   string
+		wantOk bool
+	}{
+		{raw: "accepted", want: StatusAccepted, wantOk: true},
+		{raw: "  Accepted ", want: StatusAccepted, wantOk: true},
+		{raw: "Superseded by [0019: Next](0019-next.md)", want: StatusSuperseded, wantOk: true},
+		{raw: "Superceded by [0019: Next](0019-next.md)", want: StatusSuperseded, wantOk: true},
+		{raw: "acceptedish", want: "", wantOk: false},
+		{raw: "", want: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := lifecycle.Normalize(tt.raw)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestLifecycle_Definitions(t *testing.T) {
+	lifecycle := NewLifecycle(
+		[]string{"draft", "in review", "accepted", "retired"},
+		[]string{"draft"},
+		map[string][]string{
+			"draft":     {"in review"},
+			"in review": {"draft", "accepted"},
+			"accepted":  {"retired"},
+			"retired":   {"accepted"},
+		},
+	).WithDefinitions(
+		StatusDefinition{Name: "accepted", Color: "#1a7f37", Terminal: false, Active: true},
+		StatusDefinition{Name: "retired", Color: "244", Terminal: true, Active: false},
+		StatusDefinition{Name: "unknown", Color: "#ffffff", Terminal: true, Active: true},
+	).WithAliases(map[string]string{"Deprecated": "retired", "shipped": "unknown"})
+
+	assert.Equal(t, "#1a7f37", lifecycle.Color("Accepted"))
+	assert.Equal(t, "244", lifecycle.Color("deprecated"))
+	assert.Empty(t, lifecycle.Color("draft"))
+
+	assert.True(t, lifecycle.IsActive("accepted"))
+	assert.False(t, lifecycle.IsActive("retired"))
+	assert.True(t, lifecycle.IsTerminal("Deprecated by 0007"))
+	assert.False(t, lifecycle.IsTerminal("in review"))
+
+	// unknown statuses and aliases are ignored
+	_, ok := lifecycle.Definition("unknown")
+	assert.False(t, ok)
+	_, ok = lifecycle.Normalize("shipped")
+	assert.False(t, ok)
+
+	// the legacy spelling only applies to lifecycles with a superseded status
+	_, ok = lifecycle.Normalize("superceded")
+	assert.False(t, ok)
+
+	_, ok = lifecycle.Superseded()
+	assert.False(t, ok)
+
+	lifecycle.WithSuperseded("Retired")
+	status, ok := lifecycle.Superseded()
+	assert.True(t, ok)
+	assert.Equal(t, "retired", status)
+	assert.Equal(t, "retired", lifecycle.Label("superceded"))
+
+	// terminal statuses go nowhere, whatever their transitions say
+	assert.Empty(t, lifecycle.Next("retired"))
+	require.Error(t, lifecycle.CheckTransition("retired", "accepted"))
+}
+
+func TestLifecycle_CheckTransition(t *testing.T) {
+	lifecycle := DefaultLifecycle()
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		from       string
+		to         string
+		assertFunc func(t *testing.T, err error)
+	}{
+		{
+			name: "legal",
+			from: StatusProposed,
+			to:   StatusAccepted,
+			assertFunc: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "legal initial",
+			from: "",
+			to:   StatusProposed,
+			assertFunc: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "illegal",
+			from: StatusSuperseded,
+			to:   StatusAccepted,
+			assertFunc: func(t *testing.T, err error) {
+				var transitionErr globals.StatusTransitionError
+				require.ErrorAs(t, err, &transitionErr)
+				assert.Equal(t, StatusSuperseded, transitionErr.From)
+				assert.Equal(t, StatusAccepted, transitionErr.To)
+			},
+		},
+		{
+			name: "illegal initial",
+			from: "",
+			to:   StatusSuperseded,
+			assertFunc: func(t *testing.T, err error) {
+				var transitionErr globals.StatusTransitionError
+				require.ErrorAs(t, err, &transitionErr)
+				assert.Equal(t, "superseded is not a valid initial status", transitionErr.Error())
+			},
+		},
+		{
+			name: "alias target",
+			from: StatusAccepted,
+			to:   "Superceded",
+			assertFunc: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "target with trailing text",
+			from: StatusProposed,
+			to:   "accepted pending review",
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "status", validationErr.Field)
+			},
+		},
+		{
+			name: "unknown target",
+			from: StatusProposed,
+			to:   "vibes",
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "status", validationErr.Field)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.assertFunc(t, lifecycle.CheckTransition(test.from, test.to))
+		})
+	}
+}
+
+func TestTransitionStatus(t *testing.T) {
+	lifecycle := DefaultLifecycle()
+	proposedAt := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	acceptedAt := time.Date(2024, 9, 14, 16, 30, 0, 0, time.UTC)
+
+	record := &ADR{Sequence: 1, Title: "lifecycle"}
+	require.NoError(t, record.TransitionStatus(lifecycle, StatusProposed, proposedAt))
+	require.NoError(t, record.TransitionStatus(lifecycle, "Accepted", acceptedAt))
+
+	assert.Equal(t, StatusAccepted, record.Status)
+	assert.Equal(t, acceptedAt, record.StatusChanged)
+	assert.Equal(t, []StatusChange{
+		{From: "", To: StatusProposed, At: proposedAt},
+		{From: StatusProposed, To: StatusAccepted, At: acceptedAt},
+	}, record.StatusHistory)
+
+	// illegal transitions leave the record untouched
+	err := record.TransitionStatus(lifecycle, StatusProposed, time.Now())
+	require.Error(t, err)
+	assert.Equal(t, StatusAccepted, record.Status)
+	assert.Len(t, record.StatusHistory, 2)
+}
@@ -0,0 +1,214 @@
+package adr
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// Built-in link type names. each is the slug of its forward label.
+const (
+	LinkTypeSupersedes = "supersedes"
+	LinkTypeAmends     = "amends"
+	LinkTypeClarifies  = "clarifies"
+	LinkTypeRelatesTo  = "relates-to"
+	LinkTypeDependsOn  = "depends-on"
+)
+
+// linkTypeSupersededBy is the reverse name of LinkTypeSupersedes, held by the superseded ADR.
+const linkTypeSupersededBy = "superseded-by"
+
+// LinkType describes a kind of relationship between ADRs, with a label for each direction.
+// eg: an ADR that "Amends" another is "Amended by" it.
+type LinkType struct {
+	// Forward labels the link from the source ADR
+	Forward string
+	// Reverse labels the link from the target ADR back to the source
+	Reverse string
+}
+
+// ForwardName is the stored name of the link as seen from the source ADR.
+func (lt LinkType) ForwardName() string { return utils.Slugify(lt.Forward) }
+
+// ReverseName is the stored name of the link as seen from the target ADR.
+func (lt LinkType) ReverseName() string { return utils.Slugify(lt.Reverse) }
+
+// linkTypes registers the built-in link types.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var linkTypes = []LinkType{
+	{Forward: "Supersedes", Reverse: "Superseded by"},
+	{Forward: "Amends", Reverse: "Amended by"},
+	{Forward: "Clarifies", Reverse: "Clarified by"},
+	{Forward: "Relates to", Reverse: "Relates to"},
+	{Forward: "Depends on", Reverse: "Depended on by"},
+}
+
+// LinkTypes returns the built-in link types.
+func LinkTypes() []LinkType {
+	return append([]LinkType(nil), linkTypes...)
+}
+
+// ParseLinkType resolves a link type from user input.
+// input is either the name of a built-in type, in either direction (eg: "amends", "Depends on"),
+// or a custom type given as "Forward:Reverse" labels (eg: "Extends:Extended by").
+// Returns the link type and whether input named its reverse direction.
+func ParseLinkType(input string) (LinkType, bool, error) {
+	// custom types
+	if forward, reverse, isCustom := strings.Cut(input, ":"); isCustom {
+		forward, reverse = strings.TrimSpace(forward), strings.TrimSpace(reverse)
+		if utils.Slugify(forward) == "" || utils.Slugify(reverse) == "" {
+			return LinkType{}, false, globals.ValidationError("link type", "custom types need both labels, as Forward:Reverse")
+		}
+
+		return LinkType{Forward: forward, Reverse: reverse}, false, nil
+	}
+
+	// built-in types
+	name := utils.Slugify(input)
+	for _, linkType := range linkTypes {
+		if linkType.ForwardName() == name {
+			return linkType, false, nil
+		}
+
+		if linkType.ReverseName() == name {
+			return linkType, true, nil
+		}
+	}
+
+	return LinkType{}, false, globals.ValidationError("link type", fmt.Sprintf("unknown link type %q", input))
+}
+
+// Link is a typed relationship from one ADR to another, referenced by sequence number.
+type Link struct {
+	// Type names the relationship as seen from the linking ADR, eg: "supersedes" or "superseded-by"
+	Type string `yaml:"type"`
+	// Target is the sequence number of the linked ADR
+	Target int `yaml:"target"`
+	// Label is the display label for link types that aren't built in. empty for built-in types.
+	Label string `yaml:"label,omitempty"`
+
+	// TargetTitle and TargetPath describe the linked ADR for rendering.
+	// they're resolved when the link is created or rewritten, and aren't stored in front matter.
+	TargetTitle string `yaml:"-"`
+	TargetPath  string `yaml:"-"`
+}
+
+// NewLink is a constructor. it links to target, which lives at targetPath relative to the linking document.
+func NewLink(linkType string, target *ADR, targetPath string) Link {
+	return Link{
+		Type:        linkType,
+		Target:      target.Sequence,
+		Label:       "",
+		TargetTitle: target.SequencedTitle(),
+		TargetPath:  targetPath,
+	}
+}
+
+// NewLinkPair builds both directions of a typed link between source and target.
+// the first link belongs on source and points at target; the second belongs on target and points back.
+// paths are the documents' filenames, which are relative to each other as ADRs share a directory.
+func NewLinkPair(linkType LinkType, source *ADR, sourcePath string, target *ADR, targetPath string) (Link, Link) {
+	forward := NewLink(linkType.ForwardName(), target, filepath.Base(targetPath))
+	reverse := NewLink(linkType.ReverseName(), source, filepath.Base(sourcePath))
+
+	// custom types carry their labels, as there's no registry entry to find them in
+	if !slices.Contains(linkTypes, linkType) {
+		forward.Label = linkType.Forward
+		reverse.Label = linkType.Reverse
+	}
+
+	return forward, reverse
+}
+
+// DisplayLabel returns the human label for the link: its own label for custom types, the registered label for
+// built-in types, or the type name itself as a last resort.
+func (l Link) DisplayLabel() string {
+	if l.Label != "" {
+		return l.Label
+	}
+
+	for _, linkType := range linkTypes {
+		switch l.Type {
+		case linkType.ForwardName():
+			return linkType.Forward
+		case linkType.ReverseName():
+			return linkType.Reverse
+		}
+	}
+
+	if l.Type == "" {
+		return ""
+	}
+
+	return strings.ToUpper(l.Type[:1]) + strings.ReplaceAll(l.Type[1:], "-", " ")
+}
+
+// Markdown renders the link as a short sentence holding a relative markdown link.
+// eg: "Supersedes [0004: Old Decision](0004-old-decision.md)".
+// unresolved links fall back to the bare sequence number.
+func (l Link) Markdown() string {
+	return l.Render(render.DocumentFormatMarkdown)
+}
+
+// Render renders the link as a short sentence holding a relative link in the given format. see Markdown.
+func (l Link) Render(format render.DocumentFormat) string {
+	return render.Escape(format, l.DisplayLabel()) + " " + l.TargetMarkup(format)
+}
+
+// TargetMarkup renders a relative link to the linked ADR in the given format, titled with its sequenced title.
+// eg: "[0004: Old Decision](0004-old-decision.md)". unresolved links fall back to the bare sequence number.
+func (l Link) TargetMarkup(format render.DocumentFormat) string {
+	title := l.TargetTitle
+	if title == "" {
+		title = utils.PadValue(l.Target, globals.NumericPadWidth)
+	}
+
+	return render.LinkMarkup(format, title, l.TargetPath)
+}
+
+// HasLink reports whether the ADR already holds a link of the given type to target.
+func (adr *ADR) HasLink(linkType string, target int) bool {
+	for _, link := range adr.Links {
+		if link.Type == linkType && link.Target == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveLinks fills in the title and path of each link's target by locating it in dir.
+// links whose targets can't be found are left unresolved, and render with their bare sequence number.
+func ResolveLinks(dir string, links []Link) []Link {
+	resolved := make([]Link, 0, len(links))
+
+	for _, link := range links {
+		if link.TargetTitle == "" {
+			if path, err := utils.FindBySequence(dir, link.Target); err == nil {
+				if target, _, loadErr := Load(path); loadErr == nil {
+					link.TargetTitle = target.SequencedTitle()
+					link.TargetPath = filepath.Base(path)
+				}
+			}
+		}
+
+		resolved = append(resolved, link)
+	}
+
+	return resolved
+}
+
+// SupersededByStatus returns status, the lifecycle's superseded status, for an ADR that has been superseded by
+// replacement, which lives at replacementPath relative to the superseded document. the link is written in the
+// replacement's format. eg: "superseded by [0019: New Decision](0019-new-decision.md)".
+func SupersededByStatus(status string, replacement *ADR, replacementPath string) string {
+	format, _ := render.FormatForExtension(filepath.Ext(replacementPath))
+
+	return fmt.Sprintf("%s by %s", status, render.LinkMarkup(format, replacement.SequencedTitle(), replacementPath))
+}
@@ -0,0 +1,134 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+func TestSupersedeLinks(t *testing.T) {
+	defaultTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
+	require.NoError(t, err)
+
+	superseded := &ADR{Sequence: 4, Title: "Old Decision", Status: StatusAccepted}
+	replacement := &ADR{
+		Sequence: 19,
+		Title:    "New Decision",
+		Status:   StatusAccepted,
+		Links:    []Link{NewLink(LinkTypeSupersedes, superseded, "0004-old-decision.md")},
+	}
+
+	// the replacement renders its link
+	doc, err := replacement.BuildDocument(defaultTemplate)
+	require.NoError(t, err)
+	assert.Contains(t, string(doc.Content), "\n\n## Links\n- Supersedes [0004: Old Decision](0004-old-decision.md)")
+
+	// and parses back without complaint
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []Link{{Type: LinkTypeSupersedes, Target: 4}}, parsed.Links)
+
+	// the superseded ADR links back through its status
+	status := SupersededByStatus(StatusSuperseded, replacement, doc.Filename())
+	assert.Equal(t, "superseded by [0019: New Decision](0019-new-decision.md)", status)
+	require.NoError(t, superseded.Supersede(DefaultLifecycle(), replacement, doc.Filename(), parsed.Created))
+	assert.Equal(t, status, superseded.Status)
+	assert.Equal(t, StatusSuperseded, superseded.StatusHistory[0].To)
+
+	// custom lifecycles supersede to their own status
+	statuses, initial := []string{"accepted", "retired"}, []string{"accepted"}
+	retiring := NewLifecycle(statuses, initial, map[string][]string{"accepted": {"retired"}}).WithSuperseded("retired")
+	retired := &ADR{Sequence: 5, Title: "Older Decision", Status: "accepted"}
+	require.NoError(t, retired.Supersede(retiring, replacement, doc.Filename(), parsed.Created))
+	assert.Equal(t, "retired by [0019: New Decision](0019-new-decision.md)", retired.Status)
+
+	// without one, there's nothing to supersede to
+	unretiring := NewLifecycle(statuses, initial, map[string][]string{"accepted": {"retired"}})
+	err = (&ADR{Status: "accepted"}).Supersede(unretiring, replacement, doc.Filename(), parsed.Created)
+	require.Error(t, err)
+}
+
+func TestParseLinkType(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		input      string
+		assertFunc func(t *testing.T, linkType LinkType, isReverse bool, err error)
+	}{
+		{
+			name:  "built-in",
+			input: "Depends on",
+			assertFunc: func(t *testing.T, linkType LinkType, isReverse bool, err error) {
+				require.NoError(t, err)
+				assert.False(t, isReverse)
+				assert.Equal(t, LinkTypeDependsOn, linkType.ForwardName())
+				assert.Equal(t, "depended-on-by", linkType.ReverseName())
+			},
+		},
+		{
+			name:  "built-in reverse",
+			input: "amended-by",
+			assertFunc: func(t *testing.T, linkType LinkType, isReverse bool, err error) {
+				require.NoError(t, err)
+				assert.True(t, isReverse)
+				assert.Equal(t, LinkTypeAmends, linkType.ForwardName())
+			},
+		},
+		{
+			name:  "custom",
+			input: "Extends : Extended by",
+			assertFunc: func(t *testing.T, linkType LinkType, _ bool, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, LinkType{Forward: "Extends", Reverse: "Extended by"}, linkType)
+			},
+		},
+		{
+			name:  "custom missing reverse",
+			input: "Extends:",
+			assertFunc: func(t *testing.T, _ LinkType, _ bool, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "link type", validationErr.Field)
+			},
+		},
+		{
+			name:  "unknown",
+			input: "vibes with",
+			assertFunc: func(t *testing.T, _ LinkType, _ bool, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			linkType, isReverse, err := ParseLinkType(test.input)
+			test.assertFunc(t, linkType, isReverse, err)
+		})
+	}
+}
+
+func TestNewLinkPair(t *testing.T) {
+	source := &ADR{Sequence: 12, Title: "Source"}
+	target := &ADR{Sequence: 4, Title: "Target"}
+
+	// built-in types are stored by name alone
+	amends, _, err := ParseLinkType(LinkTypeAmends)
+	require.NoError(t, err)
+
+	forward, reverse := NewLinkPair(amends, source, "/adr/0012-source.md", target, "/adr/0004-target.md")
+	assert.Equal(t, "Amends [0004: Target](0004-target.md)", forward.Markdown())
+	assert.Equal(t, "Amended by [0012: Source](0012-source.md)", reverse.Markdown())
+	assert.Empty(t, forward.Label)
+
+	// custom types carry their labels
+	forward, reverse = NewLinkPair(LinkType{Forward: "Extends", Reverse: "Extended by"}, source, "0012-source.md", target, "0004-target.md")
+	assert.Equal(t, Link{Type: "extends", Target: 4, Label: "Extends", TargetTitle: "0004: Target", TargetPath: "0004-target.md"}, forward)
+	assert.Equal(t, "Extended by [0012: Source](0012-source.md)", reverse.Markdown())
+
+	// unresolved links still render
+	assert.Equal(t, "Relates to 0007", Link{Type: LinkTypeRelatesTo, Target: 7}.Markdown())
+}
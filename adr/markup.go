@@ -0,0 +1,224 @@
+package adr
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/render"
+	"gopkg.in/yaml.v3"
+)
+
+// markup describes the syntax of a document format, as far as parsing and rewriting need it.
+// formats with underlined headings (restructuredtext) only use the list and front matter fields.
+// prefixes that are html tags are closed by their matching end tag, eg: "<h2>Context</h2>".
+type markup struct {
+	// sectionPrefix opens a section heading, eg: "## "
+	sectionPrefix string
+	// subsectionPrefix opens a subsection heading, eg: "### "
+	subsectionPrefix string
+	// titleMarker is trimmed from the title line, eg: "#"
+	titleMarker string
+	// fencePrefixes start lines that open and close blocks whose content is never a heading, eg: "```go"
+	fencePrefixes []string
+	// fenceLines are whole lines that open and close such blocks, eg: asciidoc's "----"
+	fenceLines []string
+	// bullet opens a list item, eg: "- "
+	bullet string
+	// frontMatterOpen and frontMatterClose wrap the front matter block in a comment, for formats that would otherwise
+	// render it. empty for formats that hold front matter bare.
+	frontMatterOpen  string
+	frontMatterClose string
+	// frontMatterIndent prefixes each line of a wrapped front matter block, for formats whose comments are indented.
+	// formats with an indent but no fence comment out each line, eg: org's "# "
+	frontMatterIndent string
+	// escapeDashes keeps "--" out of the front matter block, for formats whose comments it would end, eg: html's "-->"
+	escapeDashes bool
+}
+
+// markups registers the syntax of each heading-prefixed format.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var markups = map[render.DocumentFormat]markup{
+	render.DocumentFormatMarkdown: {
+		sectionPrefix:     "## ",
+		subsectionPrefix:  "### ",
+		titleMarker:       "#",
+		fencePrefixes:     []string{"```", "~~~"},
+		fenceLines:        nil,
+		bullet:            "- ",
+		frontMatterOpen:   "",
+		frontMatterClose:  "",
+		frontMatterIndent: "",
+		escapeDashes:      false,
+	},
+	render.DocumentFormatAsciiDoc: {
+		sectionPrefix:     "== ",
+		subsectionPrefix:  "=== ",
+		titleMarker:       "=",
+		fencePrefixes:     []string{"```"},
+		fenceLines:        []string{"----", "....", "////"},
+		bullet:            "* ",
+		frontMatterOpen:   "////",
+		frontMatterClose:  "////",
+		frontMatterIndent: "",
+		escapeDashes:      false,
+	},
+	render.DocumentFormatRST: {
+		sectionPrefix:     "",
+		subsectionPrefix:  "",
+		titleMarker:       "",
+		fencePrefixes:     nil,
+		fenceLines:        nil,
+		bullet:            "- ",
+		frontMatterOpen:   "..",
+		frontMatterClose:  "",
+		frontMatterIndent: "   ",
+		escapeDashes:      false,
+	},
+	render.DocumentFormatOrg: {
+		sectionPrefix:     "* ",
+		subsectionPrefix:  "** ",
+		titleMarker:       "",
+		fencePrefixes:     []string{"#+begin_", "#+end_", "#+BEGIN_", "#+END_"},
+		fenceLines:        nil,
+		bullet:            "- ",
+		frontMatterOpen:   "",
+		frontMatterClose:  "",
+		frontMatterIndent: "# ",
+		escapeDashes:      false,
+	},
+	render.DocumentFormatHTML: {
+		sectionPrefix:     "<h2>",
+		subsectionPrefix:  "<h3>",
+		titleMarker:       "",
+		fencePrefixes:     nil,
+		fenceLines:        nil,
+		bullet:            "<li>",
+		frontMatterOpen:   "<!--",
+		frontMatterClose:  "-->",
+		frontMatterIndent: "",
+		escapeDashes:      true,
+	},
+}
+
+// isFence reports whether a trimmed line opens or closes a fenced block.
+func (m markup) isFence(trimmed string) bool {
+	return slices.Contains(m.fenceLines, trimmed) || slices.ContainsFunc(m.fencePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(trimmed, prefix)
+	})
+}
+
+// headingText returns the text of a heading line opened by prefix, eg: "Context" for "<h2>Context</h2>".
+func (m markup) headingText(line, prefix string) string {
+	text := strings.TrimPrefix(strings.TrimRight(line, "\r\n"), prefix)
+	if closing := closingTag(prefix); closing != "" {
+		text = html.UnescapeString(strings.TrimSuffix(strings.TrimSpace(text), closing))
+	}
+
+	return text
+}
+
+// sectionHeading renders a section heading line, without its line ending. text is markup, as section values are.
+func (m markup) sectionHeading(text string) string {
+	return m.sectionPrefix + text + closingTag(m.sectionPrefix)
+}
+
+// list renders items as a list. html items are closed, and wrapped in a list element.
+func (m markup) list(items []string) string {
+	closing := closingTag(m.bullet)
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, m.bullet+item+closing)
+	}
+
+	if closing != "" {
+		lines = append(append([]string{"<ul>"}, lines...), "</ul>")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// closingTag returns the end tag matching an opening html tag, eg: "</h2>" for "<h2>". other markup has none.
+func closingTag(open string) string {
+	if !strings.HasPrefix(open, "<") || !strings.HasSuffix(open, ">") {
+		return ""
+	}
+
+	return "</" + open[1:]
+}
+
+// frontMatterBlock wraps raw yaml in front matter delimiters, fenced and indented as the format needs.
+// Returns an error if the yaml can't be made safe for the format's fence, see escapeCommentDashes.
+func (m markup) frontMatterBlock(rawYAML []byte) ([]byte, error) {
+	if m.escapeDashes {
+		escaped, err := escapeCommentDashes(rawYAML)
+		if err != nil {
+			return nil, err
+		}
+
+		rawYAML = escaped
+	}
+
+	var block bytes.Buffer
+
+	if m.frontMatterOpen != "" {
+		block.WriteString(m.frontMatterOpen + "\n")
+	}
+
+	lines := append(append([]string{frontMatterDelimiter}, strings.Split(strings.TrimRight(string(rawYAML), "\n"), "\n")...), frontMatterDelimiter)
+	for _, line := range lines {
+		if line != "" {
+			line = m.frontMatterIndent + line
+		} else {
+			line = strings.TrimRight(m.frontMatterIndent, " ")
+		}
+
+		block.WriteString(line + "\n")
+	}
+
+	if m.frontMatterOpen != "" {
+		block.WriteString(m.frontMatterClose + "\n")
+	}
+
+	return block.Bytes(), nil
+}
+
+// escapeCommentDashes re-encodes raw yaml so it never holds "--", which would end an html comment early: a value of
+// "--><script>" must stay a value. scalars holding "--" are double-quoted, where dashes can be escaped as "\x2d".
+// the escapes decode back to dashes, so values round-trip unchanged.
+func escapeCommentDashes(rawYAML []byte) ([]byte, error) {
+	if !bytes.Contains(rawYAML, []byte("--")) {
+		return rawYAML, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rawYAML, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding front matter: %w", err)
+	}
+
+	quoteDashes(&doc)
+
+	encoded, err := encodeYAML(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// every "--" left is inside a double-quoted scalar: the encoder only folds those at spaces, and yaml's own syntax
+	// never puts two dashes together
+	return bytes.ReplaceAll(encoded, []byte("--"), []byte(`\x2d\x2d`)), nil
+}
+
+// quoteDashes double-quotes every scalar under node that holds "--", so its dashes can be escaped.
+func quoteDashes(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "--") {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+
+	for _, child := range node.Content {
+		quoteDashes(child)
+	}
+}
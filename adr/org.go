@@ -0,0 +1,132 @@
+package adr
+
+import (
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// org-mode keywords and drawer markers, as written by the default template. org treats them case-insensitively.
+const (
+	orgTitleKeyword = "#+title:"
+	orgDateKeyword  = "#+date:"
+	orgDrawerOpen   = ":properties:"
+	orgDrawerClose  = ":end:"
+)
+
+// org-mode file-level properties the parser reads. the status property stands in for a status section.
+const (
+	orgStatusProperty = "STATUS"
+	orgTagsProperty   = "TAGS"
+)
+
+// orgPreamble is what an org-mode document declares before its first heading.
+type orgPreamble struct {
+	// keywords hold `#+KEY: value` lines, keyed by their lowercase name
+	keywords map[string]string
+	// properties hold the file-level properties drawer, keyed by their uppercase name
+	properties map[string]string
+	// propertyLines locate each property's line, for rewriting in place
+	propertyLines map[string]int
+}
+
+// scanOrgPreamble reads keywords and the properties drawer from the lines before the first heading.
+// only the first drawer is read: it's the one org applies to the whole file.
+func scanOrgPreamble(lines []string, syntax markup) orgPreamble {
+	preamble := orgPreamble{
+		keywords:      make(map[string]string),
+		properties:    make(map[string]string),
+		propertyLines: make(map[string]int),
+	}
+
+	inDrawer, drawerRead := false, false
+
+	for i, line := range lines {
+		if strings.HasPrefix(line, syntax.sectionPrefix) {
+			break
+		}
+
+		trimmed := strings.TrimSpace(line)
+		lower := strings.ToLower(trimmed)
+
+		switch {
+		case lower == orgDrawerOpen && !drawerRead:
+			inDrawer = true
+		case lower == orgDrawerClose && inDrawer:
+			inDrawer, drawerRead = false, true
+		case inDrawer:
+			// properties are `:NAME: value`
+			name, value, ok := strings.Cut(strings.TrimPrefix(trimmed, ":"), ":")
+			if ok && strings.HasPrefix(trimmed, ":") && name != "" {
+				preamble.properties[strings.ToUpper(name)] = strings.TrimSpace(value)
+				preamble.propertyLines[strings.ToUpper(name)] = i
+			}
+		case strings.HasPrefix(lower, "#+"):
+			name, value, ok := strings.Cut(trimmed, ":")
+			if ok {
+				preamble.keywords[strings.ToLower(name)+":"] = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	return preamble
+}
+
+// scanOrg splits org-mode content into its title and sections.
+// sections are top-level `* ` headings. the title comes from the `#+TITLE:` keyword, and the properties drawer fills
+// the status, tags and date, which org documents keep out of their headings.
+func scanOrg(content []byte) parsedDocument {
+	syntax := markups[render.DocumentFormatOrg]
+
+	doc := scanSections(content, syntax)
+	preamble := scanOrgPreamble(strings.Split(string(content), "\n"), syntax)
+
+	// scanSections takes the first preamble line as the title. in org, that's a keyword or the drawer.
+	doc.title = preamble.keywords[orgTitleKeyword]
+
+	if status := preamble.properties[orgStatusProperty]; status != "" {
+		section := newParsedSection(orgStatusProperty)
+		section.inline = status
+		doc.sections = append([]parsedSection{section}, doc.sections...)
+	}
+
+	doc.tags = utils.SplitList(preamble.properties[orgTagsProperty])
+	doc.created = orgDate(preamble.keywords[orgDateKeyword])
+
+	return doc
+}
+
+// orgDate reads a date from an org-mode keyword, which is either a plain date or a timestamp, eg: "<2024-09-01 Sun>".
+// returns the zero time if the value isn't a date.
+func orgDate(value string) time.Time {
+	value = strings.Trim(strings.TrimSpace(value), "<>[]")
+	if date, _, _ := strings.Cut(value, " "); date != "" {
+		if created, err := time.Parse(time.DateOnly, date); err == nil {
+			return created
+		}
+	}
+
+	return time.Time{}
+}
+
+// replaceOrgSection replaces the content of the org-mode section matching key with value.
+// the status lives in the properties drawer, and is rewritten there when present. otherwise, see replaceSection.
+func replaceOrgSection(content []byte, key, value string) ([]byte, bool) {
+	syntax := markups[render.DocumentFormatOrg]
+
+	if key == sectionStatus {
+		lines := strings.SplitAfter(string(content), "\n")
+		preamble := scanOrgPreamble(lines, syntax)
+
+		if i, found := preamble.propertyLines[orgStatusProperty]; found {
+			lineEnding := lines[i][len(strings.TrimRight(lines[i], "\r\n")):]
+			lines[i] = ":" + orgStatusProperty + ": " + value + lineEnding
+
+			return []byte(strings.Join(lines, "")), true
+		}
+	}
+
+	return replaceSection(content, syntax, key, value)
+}
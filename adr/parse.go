@@ -0,0 +1,476 @@
+package adr
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// section keys recognized by the parser. these match the headings emitted by the default template.
+const (
+	sectionStatus       = "status"
+	sectionContext      = "context"
+	sectionDecision     = "decision"
+	sectionConsequences = "consequences"
+	// links are rendered for readers, but front matter is their source of truth
+	sectionLinks = "links"
+
+	// MADR sections. see https://adr.github.io/madr/
+	sectionConsideredOptions = "considered options"
+	sectionProsAndCons       = "pros and cons of the options"
+)
+
+// sectionAliases maps headings from other templates onto the section keys they fill.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var sectionAliases = map[string]string{
+	"context and problem statement": sectionContext,
+	"decision outcome":              sectionDecision,
+}
+
+// ignoredSections are optional MADR sections that have no ADR field. they're left in the document, without warnings.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var ignoredSections = []string{"decision drivers", "more information", "validation"}
+
+// madrSections are the section keys only MADR documents have.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var madrSections = append([]string{sectionConsideredOptions, sectionProsAndCons}, ignoredSections...)
+
+// sequencedTitlePattern matches a title that carries its sequence prefix, as rendered by ADR.SequencedTitle.
+// example matches: "0007: Team Expansion", "7. Team Expansion".
+var sequencedTitlePattern = regexp.MustCompile(`^(\d+)\s*[:.]\s*(.*)$`)
+
+// WarningKind classifies a ParseWarning, so callers can act on warnings without matching their text.
+type WarningKind int
+
+// WarningKind enum.
+const (
+	// WarningInvalid is a value that couldn't be read, eg: a malformed date
+	WarningInvalid WarningKind = iota
+	// WarningMissing is an expected part of the document that wasn't found
+	WarningMissing
+	// WarningDuplicate is a repeated section. only the first one is read
+	WarningDuplicate
+	// WarningUnrecognized is content adr-er doesn't know, eg: a custom section. it isn't read
+	WarningUnrecognized
+	// WarningMismatch is two parts of the document that disagree, eg: the title's and filename's sequence
+	WarningMismatch
+)
+
+// ParseWarning reports a recoverable problem found while parsing an ADR document.
+// warnings don't prevent parsing, but they indicate the document strays from the expected structure.
+type ParseWarning struct {
+	Field  string
+	Kind   WarningKind
+	Reason string
+}
+
+func (w ParseWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Field, w.Reason)
+}
+
+// Load reads the ADR file at path and parses it into an ADR.
+// See Parse for details on the returned warnings.
+func Load(path string) (*ADR, []ParseWarning, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading ADR file %s: %w", path, err)
+	}
+
+	return Parse(path, content)
+}
+
+// Parse reads ADR content back into an ADR.
+// The sequence number is extracted from filename, which must follow the ADR naming convention.
+// An optional yaml front matter block populates the ADR's metadata.
+// The document format is determined by the filename's extension, and sections are matched by their headings.
+// Returns parse warnings for recoverable problems, like missing or unrecognized sections.
+func Parse(filename string, content []byte) (*ADR, []ParseWarning, error) {
+	format, ok := render.FormatForExtension(filepath.Ext(filename))
+	if !ok {
+		return nil, nil, globals.ValidationError("format", "unsupported format")
+	}
+
+	var warnings []ParseWarning
+
+	record := &ADR{}
+
+	// sequence from the filename
+	sequence, err := utils.SequenceFromFilename(filename)
+	if err != nil {
+		warnings = append(warnings, ParseWarning{
+			Field:  "sequence",
+			Kind:   WarningMissing,
+			Reason: "no sequence number in filename",
+		})
+	}
+
+	record.Sequence = sequence
+
+	// front matter, if present, is split off before the body is scanned
+	var meta frontMatter
+
+	if rawMeta, body, found := splitFrontMatter(content); found {
+		content = body
+
+		if yamlErr := yaml.Unmarshal(rawMeta, &meta); yamlErr != nil {
+			warnings = append(warnings, ParseWarning{Field: "front matter", Kind: WarningInvalid, Reason: yamlErr.Error()})
+		} else {
+			warnings = append(warnings, record.applyFrontMatter(meta)...)
+		}
+	}
+
+	// split the document into its title and sections
+	doc := scanDocument(format, content)
+
+	// formats that carry the date and tags in the body fall back to them. front matter takes precedence.
+	if record.Created.IsZero() {
+		record.Created = doc.created
+	}
+
+	if len(record.Tags) == 0 {
+		record.Tags = doc.tags
+	}
+
+	// the title, stripped of its sequence prefix
+	record.Title, warnings = parseTitle(doc.title, record.Sequence, warnings)
+
+	// map sections to fields. html sections are read back as the markdown they're rendered from, so they render again.
+	text := func(value string) string {
+		if format == render.DocumentFormatHTML {
+			return htmlMarkdown(value)
+		}
+
+		return value
+	}
+
+	seen := make(map[string]bool)
+
+	for _, section := range doc.sections {
+		if seen[section.key] {
+			warnings = append(warnings, ParseWarning{
+				Field:  section.key,
+				Kind:   WarningDuplicate,
+				Reason: "duplicate section ignored",
+			})
+
+			continue
+		}
+
+		seen[section.key] = true
+
+		switch section.key {
+		case sectionStatus:
+			record.Status = section.value()
+		case sectionContext:
+			record.Context = text(section.value())
+		case sectionDecision:
+			// MADR nests consequences under the decision outcome
+			decision, consequences, found := splitSubsection(section, markups[format], sectionConsequences)
+			record.Decision = text(decision.value())
+
+			if found {
+				if seen[sectionConsequences] {
+					warnings = append(warnings, ParseWarning{
+						Field:  sectionConsequences,
+						Kind:   WarningDuplicate,
+						Reason: "duplicate section ignored",
+					})
+				} else {
+					record.Consequences = text(consequences)
+					seen[sectionConsequences] = true
+				}
+			}
+		case sectionConsequences:
+			record.Consequences = text(section.value())
+		case sectionConsideredOptions:
+			record.ConsideredOptions = ListItems(text(section.value()))
+		case sectionProsAndCons:
+			record.ProsAndCons = text(section.value())
+		case sectionLinks:
+			// nothing to do: links are read from front matter
+		default:
+			if slices.Contains(ignoredSections, section.key) {
+				continue
+			}
+
+			warnings = append(warnings, ParseWarning{
+				Field:  section.key,
+				Kind:   WarningUnrecognized,
+				Reason: fmt.Sprintf("unrecognized section %q", section.heading),
+			})
+		}
+	}
+
+	// the status section is authoritative, but front matter can stand in for a missing one
+	if meta.Status != "" {
+		if !seen[sectionStatus] {
+			record.Status = meta.Status
+			seen[sectionStatus] = true
+		} else if meta.Status != record.Status {
+			warnings = append(warnings, ParseWarning{
+				Field:  sectionStatus,
+				Kind:   WarningMismatch,
+				Reason: fmt.Sprintf("front matter status %q doesn't match status section %q", meta.Status, record.Status),
+			})
+		}
+	}
+
+	// report missing sections
+	for _, key := range []string{sectionStatus, sectionContext, sectionDecision, sectionConsequences} {
+		if !seen[key] {
+			warnings = append(warnings, ParseWarning{Field: key, Kind: WarningMissing, Reason: "section not found"})
+		}
+	}
+
+	return record, warnings, nil
+}
+
+// DetectTemplate returns the id of the built-in template a document was written with: madr for documents using MADR's
+// sections or front matter, eg: "Considered Options" or `decision-makers`. anything else is default.
+func DetectTemplate(filename string, content []byte) string {
+	format, ok := render.FormatForExtension(filepath.Ext(filename))
+	if !ok {
+		return render.TemplateIDDefault
+	}
+
+	if rawMeta, body, found := splitFrontMatter(content); found {
+		content = body
+
+		var meta frontMatter
+		if yaml.Unmarshal(rawMeta, &meta) == nil &&
+			len(meta.DecisionMakers)+len(meta.Consulted)+len(meta.Informed) > 0 {
+			return render.TemplateIDMADR
+		}
+	}
+
+	for _, section := range scanDocument(format, content).sections {
+		name, _, _ := strings.Cut(section.heading, ":")
+		if _, aliased := sectionAliases[strings.ToLower(strings.TrimSpace(name))]; aliased {
+			return render.TemplateIDMADR
+		}
+
+		if slices.Contains(madrSections, section.key) {
+			return render.TemplateIDMADR
+		}
+	}
+
+	return render.TemplateIDDefault
+}
+
+// scanDocument splits content, without front matter, into its title and sections.
+func scanDocument(format render.DocumentFormat, content []byte) parsedDocument {
+	switch format {
+	case render.DocumentFormatMarkdown, render.DocumentFormatAsciiDoc:
+		return scanSections(content, markups[format])
+	case render.DocumentFormatRST:
+		return scanRST(content)
+	case render.DocumentFormatOrg:
+		return scanOrg(content)
+	case render.DocumentFormatHTML:
+		return scanHTML(content)
+	}
+
+	return parsedDocument{title: "", sections: nil, created: time.Time{}, tags: nil}
+}
+
+// parseTitle strips the sequence prefix from a document title, warning if it disagrees with the filename's sequence.
+func parseTitle(title string, sequence int, warnings []ParseWarning) (string, []ParseWarning) {
+	if title == "" {
+		return "", append(warnings, ParseWarning{Field: "title", Kind: WarningMissing, Reason: "title not found"})
+	}
+
+	matches := sequencedTitlePattern.FindStringSubmatch(title)
+	//nolint:mnd // not magic
+	if len(matches) < 3 {
+		return title, warnings
+	}
+
+	if titleSequence, err := strconv.Atoi(matches[1]); err == nil && sequence != 0 && titleSequence != sequence {
+		warnings = append(warnings, ParseWarning{
+			Field:  "sequence",
+			Kind:   WarningMismatch,
+			Reason: fmt.Sprintf("title sequence %d doesn't match filename sequence %d", titleSequence, sequence),
+		})
+	}
+
+	return strings.TrimSpace(matches[2]), warnings
+}
+
+// parsedDocument is the format-agnostic shape of an ADR document: a title and its sections, in document order.
+type parsedDocument struct {
+	title    string
+	sections []parsedSection
+	// created and tags are only set by formats that declare them outside front matter, eg: org's `#+DATE:`
+	created time.Time
+	tags    []string
+}
+
+// parsedSection is a single headed section of an ADR document.
+type parsedSection struct {
+	// key is the normalized heading name, used to match ADR fields
+	key string
+	// heading is the literal heading text
+	heading string
+	// inline holds a value given on the heading line itself, as in `## Status: accepted`
+	inline string
+	// body holds the lines under the heading
+	body []string
+}
+
+// value returns the section's content. inline values take precedence over the body.
+func (s parsedSection) value() string {
+	if s.inline != "" {
+		return s.inline
+	}
+
+	return strings.TrimSpace(strings.Join(s.body, "\n"))
+}
+
+// newParsedSection builds a section from its heading text, splitting out any inline value.
+func newParsedSection(heading string) parsedSection {
+	heading = strings.TrimSpace(heading)
+	name, inline, _ := strings.Cut(heading, ":")
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := sectionAliases[key]; ok {
+		key = alias
+	}
+
+	return parsedSection{
+		key:     key,
+		heading: heading,
+		inline:  strings.TrimSpace(inline),
+		body:    nil,
+	}
+}
+
+// splitSubsection splits the subsection (eg: `### `) matching key out of a section's body.
+// the subsection runs until the next subsection heading; anything after that stays with the section.
+// Returns the remaining section, the subsection's content, and whether the subsection was found.
+func splitSubsection(section parsedSection, syntax markup, key string) (parsedSection, string, bool) {
+	// formats with underlined headings don't mark subsections by prefix
+	if syntax.subsectionPrefix == "" {
+		return section, "", false
+	}
+
+	start, end := -1, len(section.body)
+	fenced := false
+
+	for i, line := range section.body {
+		trimmed := strings.TrimSpace(line)
+		if syntax.isFence(trimmed) {
+			fenced = !fenced
+		}
+
+		if fenced || !strings.HasPrefix(line, syntax.subsectionPrefix) {
+			continue
+		}
+
+		if start >= 0 {
+			end = i
+
+			break
+		}
+
+		if newParsedSection(syntax.headingText(line, syntax.subsectionPrefix)).key == key {
+			start = i
+		}
+	}
+
+	if start < 0 {
+		return section, "", false
+	}
+
+	subsection := strings.TrimSpace(strings.Join(section.body[start+1:end], "\n"))
+
+	remaining := section
+	remaining.body = append(slices.Clone(section.body[:start]), section.body[end:]...)
+
+	return remaining, subsection, true
+}
+
+// ListItems splits text into its list items, one per line. bullet markers are removed and blank lines are skipped.
+// example: "* postgres\n* mysql" becomes ["postgres", "mysql"].
+func ListItems(text string) []string {
+	var items []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for _, bullet := range []string{"* ", "- ", "+ "} {
+			line = strings.TrimPrefix(line, bullet)
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			items = append(items, line)
+		}
+	}
+
+	return items
+}
+
+// scanSections splits content into its title and sections, using the format's syntax, eg: `## ` sections for markdown.
+// the title is the first non-empty line before any section, with heading markup (and setext underlines) removed.
+// headings inside fenced blocks are ignored.
+func scanSections(content []byte, syntax markup) parsedDocument {
+	var (
+		doc     parsedDocument
+		current *parsedSection
+		fenced  bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// track fenced blocks, so their content is never mistaken for a heading
+		if syntax.isFence(trimmed) {
+			fenced = !fenced
+		}
+
+		// a new section
+		if !fenced && strings.HasPrefix(line, syntax.sectionPrefix) {
+			if current != nil {
+				doc.sections = append(doc.sections, *current)
+			}
+
+			section := newParsedSection(syntax.headingText(line, syntax.sectionPrefix))
+			current = &section
+
+			continue
+		}
+
+		// section content
+		if current != nil {
+			current.body = append(current.body, line)
+
+			continue
+		}
+
+		// preamble. capture the title, skipping blank lines and setext underlines
+		if doc.title == "" && trimmed != "" && strings.Trim(trimmed, "=-") != "" {
+			doc.title = strings.TrimSpace(strings.TrimLeft(trimmed, syntax.titleMarker))
+		}
+	}
+
+	if current != nil {
+		doc.sections = append(doc.sections, *current)
+	}
+
+	return doc
+}
@@ -0,0 +1,681 @@
+package adr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+// TestParse_RoundTrip guarantees that a document rendered through the default template parses back to the same ADR.
+func TestParse_RoundTrip(t *testing.T) {
+	defaultTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     7,
+		Title:        "Team Expansion",
+		Context:      "we have\n\nmany paragraphs",
+		Decision:     "hire more folks",
+		Status:       "accepted",
+		Consequences: "more standups",
+	}
+
+	doc, err := record.BuildDocument(defaultTemplate)
+	require.NoError(t, err)
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+}
+
+// TestParse_RoundTripMetadata guarantees front matter metadata survives a render-parse round trip.
+func TestParse_RoundTripMetadata(t *testing.T) {
+	defaultTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:      8,
+		Title:         "Metadata",
+		Context:       "<context>",
+		Decision:      "<decision>",
+		Status:        "accepted",
+		Consequences:  "<consequences>",
+		Created:       time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		StatusChanged: time.Date(2024, 9, 14, 0, 0, 0, 0, time.UTC),
+		Authors:       []string{"alice"},
+		Deciders:      []string{"bob", "carol"},
+		Tags:          []string{"infra"},
+		Links:         []Link{{Type: "supersedes", Target: 3}},
+		StatusHistory: []StatusChange{
+			{From: "", To: "proposed", At: time.Date(2024, 9, 1, 9, 30, 0, 0, time.UTC)},
+			{From: "proposed", To: "accepted", At: time.Date(2024, 9, 14, 16, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	doc, err := record.BuildDocument(defaultTemplate)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(doc.Content), "---\nstatus: accepted\ndate: \"2024-09-01\"\n"))
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+}
+
+// TestParse_RoundTripAsciiDoc guarantees asciidoc documents, front matter included, parse back to the same ADR,
+// and that status and link rewrites keep them readable.
+func TestParse_RoundTripAsciiDoc(t *testing.T) {
+	asciidocTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatAsciiDoc)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     10,
+		Title:        "Publish With Antora",
+		Context:      "our docs are asciidoc\n\n----\n== not a heading\n----",
+		Decision:     "write ADRs in asciidoc",
+		Status:       "proposed",
+		Consequences: "no more hand conversion",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		Tags:         []string{"docs"},
+	}
+
+	doc, err := record.BuildDocument(asciidocTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "0010-publish-with-antora.adoc", doc.Filename())
+	assert.True(t, strings.HasPrefix(string(doc.Content), "////\n---\nstatus: proposed\n"))
+	assert.Contains(t, string(doc.Content), "---\n////\n\n= 0010: Publish With Antora\n\n== Status: proposed\n")
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+
+	// rewrites keep the fenced front matter
+	record.Status = StatusAccepted
+	record.Links = []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: Old", TargetPath: "0004-old.adoc"}}
+
+	rewritten, err := RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+
+	rewritten, err = RewriteLinks(doc.Filename(), rewritten, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten), "== Status: accepted\n")
+	assert.Contains(t, string(rewritten), "== Links\n* Amends xref:0004-old.adoc[0004: Old]\n")
+
+	parsed, warnings, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, StatusAccepted, parsed.Status)
+	assert.Equal(t, []Link{{Type: LinkTypeAmends, Target: 4}}, parsed.Links)
+	assert.Equal(t, record.Context, parsed.Context)
+}
+
+// TestParse_RoundTripRST guarantees restructuredtext documents parse back to the same ADR, and that status and link
+// rewrites keep their headings valid.
+func TestParse_RoundTripRST(t *testing.T) {
+	rstTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatRST)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     11,
+		Title:        "Document In Sphinx",
+		Context:      "the toctree wants rst::\n\n    Heading\n    -------",
+		Decision:     "write ADRs in rst",
+		Status:       "proposed",
+		Consequences: "no conversion",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	doc, err := record.BuildDocument(rstTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "0011-document-in-sphinx.rst", doc.Filename())
+	assert.Equal(t,
+		"..\n   ---\n   status: proposed\n   date: \"2024-09-01\"\n   ---\n\n"+
+			"========================\n0011: Document In Sphinx\n========================\n\nStatus\n------\nproposed\n",
+		string(doc.Content[:strings.Index(string(doc.Content), "\n\nContext")+1]),
+	)
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+
+	// rewrites keep the front matter comment and the headings
+	record.Status = StatusAccepted
+	record.Links = []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: Old", TargetPath: "0004-old.rst"}}
+
+	rewritten, err := RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+
+	rewritten, err = RewriteLinks(doc.Filename(), rewritten, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten), "\n   status: accepted\n")
+	assert.Contains(t, string(rewritten), "Status\n------\naccepted\n")
+	assert.Contains(t, string(rewritten), "\n\nLinks\n-----\n- Amends :doc:`0004: Old <0004-old>`\n")
+
+	parsed, warnings, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, StatusAccepted, parsed.Status)
+	assert.Equal(t, []Link{{Type: LinkTypeAmends, Target: 4}}, parsed.Links)
+	assert.Equal(t, record.Context, parsed.Context)
+}
+
+// TestParse_RoundTripOrg guarantees org-mode documents parse back to the same ADR, and that the status is rewritten in
+// the properties drawer.
+func TestParse_RoundTripOrg(t *testing.T) {
+	orgTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatOrg)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     12,
+		Title:        "Live In Org",
+		Context:      "some of us never leave emacs\n\n#+begin_src org\n* not a heading\n#+end_src",
+		Decision:     "write ADRs in org",
+		Status:       "proposed",
+		Consequences: "** Good\nno conversion",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		Tags:         []string{"docs", "editors"},
+	}
+
+	doc, err := record.BuildDocument(orgTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "0012-live-in-org.org", doc.Filename())
+	assert.Equal(t,
+		"# ---\n# status: proposed\n# date: \"2024-09-01\"\n# tags:\n#   - docs\n#   - editors\n# ---\n\n"+
+			":PROPERTIES:\n:STATUS: proposed\n:TAGS: docs, editors\n:END:\n#+TITLE: 0012: Live In Org\n#+DATE: <2024-09-01 Sun>\n",
+		string(doc.Content[:strings.Index(string(doc.Content), "\n\n* Context")+1]),
+	)
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+
+	// rewrites keep the commented front matter and the drawer
+	record.Status = StatusAccepted
+	record.Links = []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: Old", TargetPath: "0004-old.org"}}
+
+	rewritten, err := RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+
+	rewritten, err = RewriteLinks(doc.Filename(), rewritten, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten), "\n# status: accepted\n")
+	assert.Contains(t, string(rewritten), "\n:STATUS: accepted\n")
+	assert.Contains(t, string(rewritten), "\n\n* Links\n- Amends [[file:0004-old.org][0004: Old]]\n")
+
+	parsed, warnings, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, StatusAccepted, parsed.Status)
+	assert.Equal(t, []Link{{Type: LinkTypeAmends, Target: 4}}, parsed.Links)
+	assert.Equal(t, record.Context, parsed.Context)
+}
+
+// TestParse_RoundTripHTML guarantees html documents escape user text, and that their title, status and links survive
+// parsing and rewrites. sections read back as markdown.
+func TestParse_RoundTripHTML(t *testing.T) {
+	htmlTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatHTML)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:     13,
+		Title:        "Publish <b>HTML</b> & Friends",
+		Context:      "the *intranet* takes html, eg: Result<T> and <script>alert(1)</script>",
+		Decision:     "render html",
+		Status:       "proposed",
+		Consequences: "no conversion",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	doc, err := record.BuildDocument(htmlTemplate)
+	require.NoError(t, err)
+
+	content := string(doc.Content)
+	assert.True(t, strings.HasPrefix(content, "<!--\n---\nstatus: proposed\ndate: \"2024-09-01\"\n---\n-->\n\n<!DOCTYPE html>\n"))
+	assert.Contains(t, content, "<h1>0013: Publish &lt;b&gt;HTML&lt;/b&gt; &amp; Friends</h1>")
+	assert.Contains(t, content, "<h2>Context</h2>\n<p>the <em>intranet</em> takes html, "+
+		"eg: Result&lt;T&gt; and &lt;script&gt;alert(1)&lt;/script&gt;</p>\n")
+	assert.NotContains(t, content, "<script>")
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record.Title, parsed.Title)
+	assert.Equal(t, record.Status, parsed.Status)
+	assert.Equal(t, record.Context, parsed.Context)
+	assert.Equal(t, record.Decision, parsed.Decision)
+
+	// rewrites stay inside the article
+	record.Status = StatusAccepted
+	record.Links = []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: <Old>", TargetPath: "0004-old.html"}}
+
+	rewritten, err := RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+
+	rewritten, err = RewriteLinks(doc.Filename(), rewritten, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten), "\nstatus: accepted\n")
+	assert.Contains(t, string(rewritten), "\n<h2>Status: accepted</h2>\n")
+	assert.Contains(t, string(rewritten),
+		"<h2>Links</h2>\n<ul>\n<li>Amends <a href=\"0004-old.html\">0004: &lt;Old&gt;</a></li>\n</ul>\n\n</article>\n")
+
+	parsed, warnings, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, StatusAccepted, parsed.Status)
+	assert.Equal(t, []Link{{Type: LinkTypeAmends, Target: 4}}, parsed.Links)
+
+	// statuses are escaped, but a superseding link stays a link
+	record.Status = "deprecated <script>alert(1)</script>"
+
+	rewritten, err = RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten), "\n<h2>Status: deprecated &lt;script&gt;alert(1)&lt;/script&gt;</h2>\n")
+
+	parsed, _, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Equal(t, record.Status, parsed.Status)
+
+	record.Status = SupersededByStatus(StatusSuperseded, &ADR{Sequence: 19, Title: "<New> & Better"}, "0019-new-better.html")
+
+	rewritten, err = RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+	assert.Contains(t, string(rewritten),
+		"\n<h2>Status: superseded by <a href=\"0019-new-better.html\">0019: &lt;New&gt; &amp; Better</a></h2>\n")
+}
+
+// TestParse_RoundTripHTMLFrontMatter guarantees that hostile metadata can't close the html front matter comment.
+func TestParse_RoundTripHTMLFrontMatter(t *testing.T) {
+	htmlTemplate, err := render.DefaultTemplateForFormat(render.DocumentFormatHTML)
+	require.NoError(t, err)
+
+	hostile := "--><script>alert(1)</script>"
+	record := &ADR{
+		Sequence:     14,
+		Title:        "Hostile Metadata",
+		Context:      "c",
+		Decision:     "d",
+		Status:       "proposed",
+		Consequences: "q",
+		Created:      time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		Authors:      []string{"a--b", hostile},
+		Tags:         []string{hostile, "safe"},
+	}
+
+	doc, err := record.BuildDocument(htmlTemplate)
+	require.NoError(t, err)
+
+	// the hostile values stay inside the comment: only the fence closes it
+	content := string(doc.Content)
+	comment, page, found := strings.Cut(content, "-->")
+	require.True(t, found)
+	assert.True(t, strings.HasPrefix(page, "\n\n<!DOCTYPE html>"))
+	assert.NotContains(t, page, "<script>")
+	assert.Equal(t, 3, strings.Count(comment, "--"), "only the opening fence and the two delimiters")
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record.Authors, parsed.Authors)
+	assert.Equal(t, record.Tags, parsed.Tags)
+
+	// rewrites re-encode the front matter, and stay safe
+	record.Status = StatusAccepted
+	record.Links = []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: " + hostile, TargetPath: "0004-old.html"}}
+
+	rewritten, err := RewriteStatus(doc.Filename(), doc.Content, record)
+	require.NoError(t, err)
+
+	rewritten, err = RewriteLinks(doc.Filename(), rewritten, record)
+	require.NoError(t, err)
+
+	_, page, _ = strings.Cut(string(rewritten), "-->")
+	assert.NotContains(t, page, "<script>")
+
+	parsed, _, err = Parse(doc.Filename(), rewritten)
+	require.NoError(t, err)
+	assert.Equal(t, record.Tags, parsed.Tags)
+	assert.Equal(t, StatusAccepted, parsed.Status)
+}
+
+// TestParse_RoundTripMADR guarantees that a document rendered through the MADR template parses back to the same ADR.
+func TestParse_RoundTripMADR(t *testing.T) {
+	madrTemplate, err := render.TemplateForFormat(render.TemplateIDMADR, render.DocumentFormatMarkdown)
+	require.NoError(t, err)
+
+	record := &ADR{
+		Sequence:          9,
+		Title:             "Use Postgres",
+		Context:           "we need a database",
+		Decision:          `Chosen option: "postgres", because it's boring`,
+		Status:            "accepted",
+		Consequences:      "* Good, because we know it\n* Bad, because we run it",
+		Created:           time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		Deciders:          []string{"bob"},
+		ConsideredOptions: []string{"postgres", "mysql"},
+		ProsAndCons:       "### postgres\n\n* Good, because it's boring",
+		Consulted:         []string{"dba team"},
+		Informed:          []string{"everyone"},
+	}
+
+	doc, err := record.BuildDocument(madrTemplate)
+	require.NoError(t, err)
+	assert.Contains(t, string(doc.Content), "## Considered Options\n\n* postgres\n* mysql\n")
+
+	parsed, warnings, err := Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+
+	// without options, there's no empty heading for them
+	record.ConsideredOptions = nil
+
+	doc, err = record.BuildDocument(madrTemplate)
+	require.NoError(t, err)
+	assert.NotContains(t, string(doc.Content), "Considered Options")
+	assert.Contains(t, string(doc.Content), "\n\nwe need a database\n\n## Decision Outcome\n")
+
+	parsed, warnings, err = Parse(doc.Filename(), doc.Content)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, record, parsed)
+}
+
+func TestDetectTemplate(t *testing.T) {
+	rendered := func(templateID string, format render.DocumentFormat) func(t *testing.T) (string, []byte) {
+		return func(t *testing.T) (string, []byte) {
+			t.Helper()
+
+			tpl, err := render.TemplateForFormat(templateID, format)
+			require.NoError(t, err)
+
+			doc, err := (&ADR{Sequence: 1, Title: "Use Postgres", Status: "proposed"}).BuildDocument(tpl)
+			require.NoError(t, err)
+
+			return doc.Filename(), doc.Content
+		}
+	}
+	written := func(filename, content string) func(t *testing.T) (string, []byte) {
+		return func(*testing.T) (string, []byte) { return filename, []byte(content) }
+	}
+
+	tests := []struct {
+		name     string
+		document func(t *testing.T) (string, []byte)
+		want     string
+	}{
+		{
+			name:     "default template",
+			document: rendered(render.TemplateIDDefault, render.DocumentFormatMarkdown),
+			want:     "default",
+		},
+		{
+			name:     "default template in org",
+			document: rendered(render.TemplateIDDefault, render.DocumentFormatOrg),
+			want:     "default",
+		},
+		{
+			name:     "madr template",
+			document: rendered(render.TemplateIDMADR, render.DocumentFormatMarkdown),
+			want:     "madr",
+		},
+		{
+			name:     "madr decision-makers",
+			document: written("0001-a.md", "---\ndecision-makers: [jane]\n---\n# A\n\n## Context\nc\n"),
+			want:     "madr",
+		},
+		{
+			name:     "madr sections",
+			document: written("0001-a.md", "# A\n\n## Context and Problem Statement\nc\n"),
+			want:     "madr",
+		},
+		{name: "unknown format", document: written("0001-a.txt", "## Considered Options\n"), want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename, content := tt.document(t)
+			assert.Equal(t, tt.want, DetectTemplate(filename, content))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		filename   string
+		content    string
+		assertFunc func(t *testing.T, record *ADR, warnings []ParseWarning, err error)
+	}{
+		{
+			name:     "madr",
+			filename: "0004-use-plain-junit5.md",
+			content: `---
+status: accepted
+date: 2024-05-01
+decision-makers: [alice, bob]
+consulted: [carol]
+---
+# Use Plain JUnit5 for TDD
+
+## Context and Problem Statement
+
+How to write readable test assertions?
+
+## Decision Drivers
+
+* readability
+
+## Considered Options
+
+* Plain JUnit5
+* Hamcrest
+- AssertJ
+
+## Decision Outcome
+
+Chosen option: "Plain JUnit5", because it comes out best.
+
+### Consequences
+
+* Good, because tests are more readable
+
+### Confirmation
+
+reviews
+
+## More Information
+
+none
+`,
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, warnings)
+				assert.Equal(t, "Use Plain JUnit5 for TDD", record.Title)
+				assert.Equal(t, "accepted", record.Status)
+				assert.Equal(t, "How to write readable test assertions?", record.Context)
+				assert.Equal(t, []string{"Plain JUnit5", "Hamcrest", "AssertJ"}, record.ConsideredOptions)
+				assert.Equal(t, "Chosen option: \"Plain JUnit5\", because it comes out best.\n\n### Confirmation\n\nreviews", record.Decision)
+				assert.Equal(t, "* Good, because tests are more readable", record.Consequences)
+				assert.Equal(t, []string{"alice", "bob"}, record.Deciders)
+				assert.Equal(t, []string{"carol"}, record.Consulted)
+			},
+		},
+		{
+			name:     "hand-edited",
+			filename: "0003-security-audit.md",
+			content: `# 0003: Security Audit
+
+## Status
+accepted
+
+## Context
+` + "```" + `
+## not a heading
+` + "```" + `
+
+## Decision
+audit everything
+
+## Consequences
+fewer surprises
+`,
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, warnings)
+				assert.Equal(t, 3, record.Sequence)
+				assert.Equal(t, "Security Audit", record.Title)
+				assert.Equal(t, "accepted", record.Status)
+				assert.Equal(t, "```\n## not a heading\n```", record.Context)
+				assert.Equal(t, "audit everything", record.Decision)
+				assert.Equal(t, "fewer surprises", record.Consequences)
+			},
+		},
+		{
+			name:     "missing and unknown sections",
+			filename: "0004-sparse.md",
+			content: `0004: Sparse
+---
+
+## Status: proposed
+
+## Notes
+some notes
+`,
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Sparse", record.Title)
+				assert.Equal(t, "proposed", record.Status)
+				assert.ElementsMatch(t, []ParseWarning{
+					{Field: "notes", Kind: WarningUnrecognized, Reason: `unrecognized section "Notes"`},
+					{Field: "context", Kind: WarningMissing, Reason: "section not found"},
+					{Field: "decision", Kind: WarningMissing, Reason: "section not found"},
+					{Field: "consequences", Kind: WarningMissing, Reason: "section not found"},
+				}, warnings)
+			},
+		},
+		{
+			name:     "sequence mismatch",
+			filename: "0005-mismatch.md",
+			content:  "0006: Mismatch\n---\n\n## Status: proposed\n## Context\n## Decision\n## Consequences\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 5, record.Sequence)
+				assert.Equal(t, []ParseWarning{
+					{Field: "sequence", Kind: WarningMismatch, Reason: "title sequence 6 doesn't match filename sequence 5"},
+				}, warnings)
+			},
+		},
+		{
+			name:     "front matter stands in for status",
+			filename: "0009-front-matter.md",
+			content:  "---\nstatus: accepted\ndate: 09/01/2024\ntags: [infra]\n---\n0009: Front Matter\n---\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Front Matter", record.Title)
+				assert.Equal(t, "accepted", record.Status)
+				assert.Equal(t, []string{"infra"}, record.Tags)
+				assert.True(t, record.Created.IsZero())
+				assert.Contains(t, warnings, ParseWarning{
+					Field:  "date",
+					Kind:   WarningInvalid,
+					Reason: `invalid date "09/01/2024", expected YYYY-MM-DD`,
+				})
+			},
+		},
+		{
+			name:     "front matter disagrees with status section",
+			filename: "0010-disagree.md",
+			content:  "---\nstatus: accepted\n---\n0010: Disagree\n---\n\n## Status: proposed\n",
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "proposed", record.Status)
+				assert.Contains(t, warnings, ParseWarning{
+					Field:  "status",
+					Kind:   WarningMismatch,
+					Reason: `front matter status "accepted" doesn't match status section "proposed"`,
+				})
+			},
+		},
+		{
+			name:     "hand-written org",
+			filename: "0011-org-notes.org",
+			content: `#+title: Org Notes
+#+date: [2024-09-01 Sun 10:00]
+:properties:
+:Status: accepted
+:TAGS: editors, docs
+:end:
+
+* Context
+#+BEGIN_EXAMPLE
+* not a heading
+#+END_EXAMPLE
+
+* Decision
+keep notes in org
+** Rationale
+it's what we use
+
+* Consequences
+- fewer conversions
+`,
+			assertFunc: func(t *testing.T, record *ADR, warnings []ParseWarning, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, warnings)
+				assert.Equal(t, "Org Notes", record.Title)
+				assert.Equal(t, "accepted", record.Status)
+				assert.Equal(t, time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), record.Created)
+				assert.Equal(t, []string{"editors", "docs"}, record.Tags)
+				assert.Equal(t, "#+BEGIN_EXAMPLE\n* not a heading\n#+END_EXAMPLE", record.Context)
+				assert.Equal(t, "keep notes in org\n** Rationale\nit's what we use", record.Decision)
+				assert.Equal(t, "- fewer conversions", record.Consequences)
+			},
+		},
+		{
+			name:     "unsupported format",
+			filename: "0001-binary.exe",
+			content:  "content",
+			assertFunc: func(t *testing.T, record *ADR, _ []ParseWarning, err error) {
+				assert.Nil(t, record)
+
+				var validationError globals.InputValidationError
+				require.ErrorAs(t, err, &validationError)
+				assert.Equal(t, "format", validationError.Field)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record, warnings, err := Parse(test.filename, []byte(test.content))
+			test.assertFunc(t, record, warnings, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "0002-loaded.md")
+	require.NoError(t, os.WriteFile(path, []byte("0002: Loaded\n---\n\n## Status: accepted\n"), 0o600))
+
+	record, _, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, record.Sequence)
+	assert.Equal(t, "Loaded", record.Title)
+	assert.Equal(t, "accepted", record.Status)
+
+	_, _, err = Load(filepath.Join(dir, "0003-missing.md"))
+	require.Error(t, err)
+}
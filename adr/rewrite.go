@@ -0,0 +1,312 @@
+package adr
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"gopkg.in/yaml.v3"
+)
+
+// RewriteStatus rewrites the status of an existing ADR document to match record.
+// Only the status section and, if present, the front matter's status fields are changed. The rest of the document,
+// including any hand edits, is preserved byte-for-byte.
+// filename is used to determine the document format.
+func RewriteStatus(filename string, content []byte, record *ADR) ([]byte, error) {
+	format, _ := render.FormatForExtension(filepath.Ext(filename))
+	meta := record.frontMatter()
+
+	return rewriteDocument(filename, content, sectionRewrite{
+		key:     sectionStatus,
+		heading: "Status",
+		value:   statusMarkup(format, record.Status),
+		fields: map[string]any{
+			"status":         meta.Status,
+			"status-changed": meta.StatusChanged,
+			"history":        meta.History,
+		},
+		required: true,
+	})
+}
+
+// supersededLinkPattern matches an html status ending in the relative link SupersededByStatus writes, eg:
+// `superseded by <a href="0019-new-decision.html">0019: New Decision</a>`.
+var supersededLinkPattern = regexp.MustCompile(`^(.+ by )(<a href="[^":<>]*">[^<>]*</a>)$`)
+
+// statusMarkup renders a status as markup for format. the status is escaped, except for the link to a superseding
+// ADR, which is markup already.
+func statusMarkup(format render.DocumentFormat, status string) string {
+	if format == render.DocumentFormatHTML {
+		if match := supersededLinkPattern.FindStringSubmatch(status); match != nil {
+			return render.Escape(format, match[1]) + match[2]
+		}
+	}
+
+	return render.Escape(format, status)
+}
+
+// RewriteLinks rewrites the links of an existing ADR document to match record.
+// The links section is replaced, or appended if the document doesn't have one yet, and the front matter's links are
+// updated. Since front matter is where links are read from, a front matter block is added if the document has none.
+// The rest of the document is preserved byte-for-byte.
+// filename is used to determine the document format.
+func RewriteLinks(filename string, content []byte, record *ADR) ([]byte, error) {
+	format, _ := render.FormatForExtension(filepath.Ext(filename))
+
+	rendered := make([]string, 0, len(record.Links))
+	for _, link := range record.Links {
+		rendered = append(rendered, link.Render(format))
+	}
+
+	return rewriteDocument(filename, content, sectionRewrite{
+		key:      sectionLinks,
+		heading:  "Links",
+		value:    markups[format].list(rendered),
+		fields:   map[string]any{"links": record.Links},
+		required: false,
+	})
+}
+
+// sectionRewrite describes an in-place change to a single section and its front matter fields.
+type sectionRewrite struct {
+	// key and heading identify the section
+	key     string
+	heading string
+	// value is the section's new content
+	value string
+	// fields are the front matter fields to set alongside the section
+	fields map[string]any
+	// required sections must already exist, either as a section or in front matter.
+	// otherwise, missing sections are appended and a front matter block is created as needed.
+	required bool
+}
+
+// rewriteDocument applies a sectionRewrite to a document, leaving everything else untouched.
+func rewriteDocument(filename string, content []byte, change sectionRewrite) ([]byte, error) {
+	format, ok := render.FormatForExtension(filepath.Ext(filename))
+	if !ok {
+		return nil, globals.ValidationError("format", "unsupported format")
+	}
+
+	rawMeta, body, hasFrontMatter := splitFrontMatter(content)
+
+	// the section
+	var (
+		rewritten []byte
+		found     bool
+	)
+
+	switch format {
+	case render.DocumentFormatMarkdown, render.DocumentFormatAsciiDoc:
+		rewritten, found = replaceSection(body, markups[format], change.key, change.value)
+		if !found && !change.required {
+			rewritten, found = appendSection(body, markups[format], change.heading, change.value), true
+		}
+	case render.DocumentFormatRST:
+		rewritten, found = replaceRSTSection(body, change.key, change.value)
+		if !found && !change.required {
+			rewritten, found = appendRSTSection(body, change.heading, change.value), true
+		}
+	case render.DocumentFormatHTML:
+		rewritten, found = replaceHTMLSection(body, change.key, change.heading, change.value, !change.required)
+	case render.DocumentFormatOrg:
+		rewritten, found = replaceOrgSection(body, change.key, change.value)
+		if !found && !change.required {
+			rewritten, found = appendSection(body, markups[format], change.heading, change.value), true
+		}
+	}
+
+	// a document needs somewhere to hold the value
+	if !found && !hasFrontMatter {
+		return nil, globals.ValidationError(change.key, fmt.Sprintf("document has no %s section", change.key))
+	}
+
+	if !hasFrontMatter && change.required {
+		return rewritten, nil
+	}
+
+	// the front matter fields
+	updatedMeta, err := setFrontMatterFields(rawMeta, change.fields)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := markups[format].frontMatterBlock(updatedMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bytes.Buffer
+
+	document.Write(block)
+
+	// new front matter blocks are separated from the body, as the templates do
+	if !hasFrontMatter {
+		document.WriteString("\n")
+	}
+
+	document.Write(rewritten)
+
+	return document.Bytes(), nil
+}
+
+// setFrontMatterFields sets top-level keys in a raw yaml front matter block, leaving all other keys in place.
+// keys that don't exist yet are appended, and keys with empty values are removed, mirroring omitempty.
+func setFrontMatterFields(rawMeta []byte, fields map[string]any) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rawMeta, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding front matter: %w", err)
+	}
+
+	// an empty block decodes to a zero node. give it an empty mapping to hold the fields.
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, globals.ValidationError("front matter", "front matter is not a yaml mapping")
+	}
+
+	mapping := doc.Content[0]
+
+	// iterate in a stable order, so appended keys are deterministic
+	for _, key := range sortedKeys(fields) {
+		var value yaml.Node
+		if err := value.Encode(fields[key]); err != nil {
+			return nil, fmt.Errorf("error encoding front matter field %s: %w", key, err)
+		}
+
+		empty := (value.Kind == yaml.ScalarNode && value.Value == "") ||
+			(value.Kind == yaml.SequenceNode && len(value.Content) == 0)
+		replaced := false
+
+		// mapping content alternates key, value
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			if mapping.Content[i].Value != key {
+				continue
+			}
+
+			if empty {
+				mapping.Content = slices.Delete(mapping.Content, i, i+2)
+			} else {
+				mapping.Content[i+1] = &value
+			}
+
+			replaced = true
+
+			break
+		}
+
+		if !replaced && !empty {
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+		}
+	}
+
+	return encodeYAML(&doc)
+}
+
+// replaceSection replaces the content of the section (eg: `## `) matching key with value.
+// inline sections (`## Status: accepted`) are rewritten on the heading line. for block sections, only the non-blank
+// body is replaced, so surrounding whitespace is kept as-is. Returns the content and whether the section was found.
+func replaceSection(content []byte, syntax markup, key, value string) ([]byte, bool) {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	// locate the section heading and the heading that follows it
+	start, end := -1, len(lines)
+	fenced := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if syntax.isFence(trimmed) {
+			fenced = !fenced
+		}
+
+		if fenced || !strings.HasPrefix(line, syntax.sectionPrefix) {
+			continue
+		}
+
+		if start >= 0 {
+			end = i
+
+			break
+		}
+
+		if newParsedSection(syntax.headingText(line, syntax.sectionPrefix)).key == key {
+			start = i
+		}
+	}
+
+	if start < 0 {
+		return content, false
+	}
+
+	heading := strings.TrimSpace(syntax.headingText(lines[start], syntax.sectionPrefix))
+	lineEnding := lines[start][len(strings.TrimRight(lines[start], "\r\n")):]
+
+	// inline: rewrite the heading line
+	if name, _, isInline := strings.Cut(heading, ":"); isInline {
+		lines[start] = syntax.sectionHeading(name+": "+value) + lineEnding
+
+		return []byte(strings.Join(lines, "")), true
+	}
+
+	return replaceSectionBody(lines, start, end, value), true
+}
+
+// replaceSectionBody replaces the body of a block section, whose heading ends at lines[headingEnd] and whose body runs
+// until lines[end], with value. only the span between the first and last non-blank body lines is replaced, so
+// surrounding whitespace is kept as-is.
+func replaceSectionBody(lines []string, headingEnd, end int, value string) []byte {
+	first, last := -1, -1
+
+	for i := headingEnd + 1; i < end; i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		last = i
+	}
+
+	var updated []string
+
+	if first < 0 {
+		// empty section: the value goes directly under the heading
+		if !strings.HasSuffix(lines[headingEnd], "\n") {
+			lines[headingEnd] += "\n"
+		}
+
+		updated = append(updated, lines[:headingEnd+1]...)
+		updated = append(updated, value+"\n")
+		updated = append(updated, lines[headingEnd+1:]...)
+	} else {
+		// keep the original line ending, including a missing one at EOF
+		replacement := value + lines[last][len(strings.TrimRight(lines[last], "\r\n")):]
+
+		updated = append(updated, lines[:first]...)
+		updated = append(updated, replacement)
+		updated = append(updated, lines[last+1:]...)
+	}
+
+	return []byte(strings.Join(updated, ""))
+}
+
+// appendSection adds a new section (eg: `## `) to the end of content, separated by a blank line.
+func appendSection(content []byte, syntax markup, heading, value string) []byte {
+	trimmed := bytes.TrimRight(content, "\r\n")
+
+	var appended bytes.Buffer
+
+	appended.Write(trimmed)
+	appended.WriteString("\n\n" + syntax.sectionHeading(heading) + "\n" + value + "\n")
+
+	return appended.Bytes()
+}
@@ -0,0 +1,120 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestRewriteStatus(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		content    string
+		assertFunc func(t *testing.T, rewritten []byte, err error)
+	}{
+		{
+			name:    "inline status",
+			content: "0001: Inline\n---\n\n## Status: proposed\n\n## Context\n  *hand*  edited  \n",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "0001: Inline\n---\n\n## Status: accepted\n\n## Context\n  *hand*  edited  \n", string(rewritten))
+			},
+		},
+		{
+			name:    "block status",
+			content: "# 0001: Block\n\n## Status\n\nproposed\n\n## Context\nctx",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "# 0001: Block\n\n## Status\n\naccepted\n\n## Context\nctx", string(rewritten))
+			},
+		},
+		{
+			name:    "empty block status at eof",
+			content: "# 0001: Empty\n\n## Status",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "# 0001: Empty\n\n## Status\naccepted\n", string(rewritten))
+			},
+		},
+		{
+			name:    "front matter",
+			content: "---\n# keep this comment\nstatus: proposed\ncustom: value\n---\n0001: Front\n---\n\n## Status: proposed\n",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t,
+					"---\n# keep this comment\nstatus: accepted\ncustom: value\n---\n0001: Front\n---\n\n## Status: accepted\n",
+					string(rewritten),
+				)
+			},
+		},
+		{
+			name:    "no status anywhere",
+			content: "0001: Nothing\n---\n\n## Context\n",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				assert.Nil(t, rewritten)
+
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "status", validationErr.Field)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rewritten, err := RewriteStatus("0001-test.md", []byte(test.content), &ADR{Status: StatusAccepted})
+			test.assertFunc(t, rewritten, err)
+		})
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	record := &ADR{Links: []Link{{Type: LinkTypeAmends, Target: 4, TargetTitle: "0004: Old", TargetPath: "0004-old.md"}}}
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		content    string
+		assertFunc func(t *testing.T, rewritten []byte, err error)
+	}{
+		{
+			name:    "appends section and front matter",
+			content: "0012: New\n---\n\n## Status: accepted\n\n## Consequences\nsome\n\n",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t,
+					"---\nlinks:\n  - type: amends\n    target: 4\n---\n\n"+
+						"0012: New\n---\n\n## Status: accepted\n\n## Consequences\nsome\n\n## Links\n- Amends [0004: Old](0004-old.md)\n",
+					string(rewritten),
+				)
+
+				// and it reads back
+				parsed, _, parseErr := Parse("0012-new.md", rewritten)
+				require.NoError(t, parseErr)
+				assert.Equal(t, []Link{{Type: LinkTypeAmends, Target: 4}}, parsed.Links)
+			},
+		},
+		{
+			name:    "replaces section",
+			content: "---\nstatus: accepted\n---\n0012: New\n---\n\n## Links\n- Relates to 0003\n\n## Notes\nkeep\n",
+			assertFunc: func(t *testing.T, rewritten []byte, err error) {
+				require.NoError(t, err)
+				assert.Equal(t,
+					"---\nstatus: accepted\nlinks:\n  - type: amends\n    target: 4\n---\n"+
+						"0012: New\n---\n\n## Links\n- Amends [0004: Old](0004-old.md)\n\n## Notes\nkeep\n",
+					string(rewritten),
+				)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rewritten, err := RewriteLinks("0012-new.md", []byte(test.content), record)
+			test.assertFunc(t, rewritten, err)
+		})
+	}
+}
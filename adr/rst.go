@@ -0,0 +1,174 @@
+package adr
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+// rstAdornmentChars are the characters restructuredtext accepts as section adornments.
+const rstAdornmentChars = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// rstHeading is a restructuredtext section title, along with its adornment.
+type rstHeading struct {
+	// start and end bound the heading's lines, including any overline. end is exclusive.
+	start, end int
+	// text is the title text
+	text string
+	// style identifies the heading's level: its adornment character, and whether it's overlined
+	style string
+}
+
+// rstHeadings finds the section titles in lines. titles are a line of text underlined, and optionally overlined, with
+// a run of one adornment character at least as wide as the text. indented lines, as in literal blocks, are never titles.
+func rstHeadings(lines []string) []rstHeading {
+	var headings []rstHeading
+
+	for i := 0; i+1 < len(lines); i++ {
+		// overlined: adornment, text, adornment
+		if over, ok := rstAdornment(lines[i]); ok && i+2 < len(lines) {
+			text := strings.TrimSpace(lines[i+1])
+			if under, underOK := rstAdornment(lines[i+2]); underOK && under == over && text != "" &&
+				runewidth.StringWidth(strings.TrimRight(lines[i+2], "\r\n")) >= runewidth.StringWidth(text) {
+				headings = append(headings, rstHeading{start: i, end: i + 3, text: text, style: "over" + string(over)})
+				i += 2
+
+				continue
+			}
+		}
+
+		// underlined: text, adornment. the text must follow a blank line, or open the document.
+		text := strings.TrimRight(lines[i], "\r\n")
+		if text == "" || text[0] == ' ' || text[0] == '\t' || (i > 0 && strings.TrimSpace(lines[i-1]) != "") {
+			continue
+		}
+
+		if _, isAdornment := rstAdornment(text); isAdornment {
+			continue
+		}
+
+		if under, ok := rstAdornment(lines[i+1]); ok &&
+			runewidth.StringWidth(strings.TrimRight(lines[i+1], "\r\n")) >= runewidth.StringWidth(text) {
+			headings = append(headings, rstHeading{start: i, end: i + 2, text: strings.TrimSpace(text), style: string(under)})
+			i++
+		}
+	}
+
+	return headings
+}
+
+// rstAdornment reports whether line is a section adornment, returning its character.
+func rstAdornment(line string) (byte, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" || !strings.ContainsRune(rstAdornmentChars, rune(line[0])) {
+		return 0, false
+	}
+
+	if strings.Trim(line, line[:1]) != "" {
+		return 0, false
+	}
+
+	return line[0], true
+}
+
+// rstSections returns the headings that open sections: those styled like the first heading after the title.
+// deeper headings stay within their section's body.
+func rstSections(headings []rstHeading) []rstHeading {
+	//nolint:mnd // not magic: a title, then at least one section
+	if len(headings) < 2 {
+		return nil
+	}
+
+	var sections []rstHeading
+
+	for _, heading := range headings[1:] {
+		if heading.style == headings[1].style {
+			sections = append(sections, heading)
+		}
+	}
+
+	return sections
+}
+
+// scanRST splits restructuredtext content into its title and sections.
+// the title is the document's first heading, and sections are the headings below it at the next level.
+func scanRST(content []byte) parsedDocument {
+	var doc parsedDocument
+
+	lines := strings.Split(string(content), "\n")
+	headings := rstHeadings(lines)
+
+	if len(headings) == 0 {
+		return doc
+	}
+
+	doc.title = headings[0].text
+
+	sections := rstSections(headings)
+	for i, heading := range sections {
+		end := len(lines)
+		if i+1 < len(sections) {
+			end = sections[i+1].start
+		}
+
+		section := newParsedSection(heading.text)
+		section.body = lines[heading.end:end]
+		doc.sections = append(doc.sections, section)
+	}
+
+	return doc
+}
+
+// replaceRSTSection replaces the content of the restructuredtext section matching key with value.
+// inline sections (`Status: accepted`) are rewritten in the title, with its adornment resized to match.
+// Returns the content and whether the section was found. see replaceSection.
+func replaceRSTSection(content []byte, key, value string) ([]byte, bool) {
+	lines := strings.SplitAfter(string(content), "\n")
+	sections := rstSections(rstHeadings(lines))
+
+	for i, heading := range sections {
+		if newParsedSection(heading.text).key != key {
+			continue
+		}
+
+		end := len(lines)
+		if i+1 < len(sections) {
+			end = sections[i+1].start
+		}
+
+		// inline: rewrite the title and its adornment
+		if name, _, isInline := strings.Cut(heading.text, ":"); isInline {
+			text := name + ": " + value
+
+			for j := heading.start; j < heading.end; j++ {
+				lineEnding := lines[j][len(strings.TrimRight(lines[j], "\r\n")):]
+
+				if char, isAdornment := rstAdornment(lines[j]); isAdornment {
+					lines[j] = render.Underline(string(char), text) + lineEnding
+				} else {
+					lines[j] = text + lineEnding
+				}
+			}
+
+			return []byte(strings.Join(lines, "")), true
+		}
+
+		return replaceSectionBody(lines, heading.end-1, end, value), true
+	}
+
+	return content, false
+}
+
+// appendRSTSection adds a new restructuredtext section to the end of content, separated by a blank line.
+func appendRSTSection(content []byte, heading, value string) []byte {
+	trimmed := bytes.TrimRight(content, "\r\n")
+
+	var appended bytes.Buffer
+
+	appended.Write(trimmed)
+	appended.WriteString("\n\n" + heading + "\n" + render.Underline(render.RSTSectionUnderline, heading) + "\n" + value + "\n")
+
+	return appended.Bytes()
+}
@@ -0,0 +1,67 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanRST(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		content    string
+		assertFunc func(t *testing.T, doc parsedDocument)
+	}{
+		{
+			name:    "underlined title, subsections stay in the body",
+			content: "Use Sphinx\n==========\n\nContext\n-------\nwe write docs\n\nDetail\n~~~~~~\nmore\n\nDecision\n--------\nsphinx\n",
+			assertFunc: func(t *testing.T, doc parsedDocument) {
+				assert.Equal(t, "Use Sphinx", doc.title)
+				require.Len(t, doc.sections, 2)
+				assert.Equal(t, sectionContext, doc.sections[0].key)
+				assert.Equal(t, "we write docs\n\nDetail\n~~~~~~\nmore", doc.sections[0].value())
+				assert.Equal(t, "sphinx", doc.sections[1].value())
+			},
+		},
+		{
+			name:    "inline status, short underlines and indented text aren't headings",
+			content: "=====\nTitle\n=====\n\nStatus: accepted\n----------------\n\nContext\n---\nliteral::\n\n  Not\n  ---\n",
+			assertFunc: func(t *testing.T, doc parsedDocument) {
+				assert.Equal(t, "Title", doc.title)
+				require.Len(t, doc.sections, 1)
+				assert.Equal(t, sectionStatus, doc.sections[0].key)
+				assert.Equal(t, "accepted", doc.sections[0].value())
+				assert.Contains(t, doc.sections[0].body, "Context")
+			},
+		},
+		{
+			name:    "wide characters take two columns",
+			content: "採用決定\n========\n\n状況\n----\nok\n\n決定\n--\nnot a heading\n",
+			assertFunc: func(t *testing.T, doc parsedDocument) {
+				assert.Equal(t, "採用決定", doc.title)
+				require.Len(t, doc.sections, 1)
+				assert.Equal(t, "状況", doc.sections[0].heading)
+				assert.Equal(t, "ok\n\n決定\n--\nnot a heading", doc.sections[0].value())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertFunc(t, scanRST([]byte(tt.content)))
+		})
+	}
+}
+
+func TestReplaceRSTSection(t *testing.T) {
+	content := "Title\n=====\n\nStatus: proposed\n----------------\n\nContext\n-------\nctx\n"
+
+	rewritten, found := replaceRSTSection([]byte(content), sectionStatus, "superseded")
+	require.True(t, found)
+	assert.Equal(t, "Title\n=====\n\nStatus: superseded\n------------------\n\nContext\n-------\nctx\n", string(rewritten))
+
+	_, found = replaceRSTSection([]byte(content), sectionDecision, "nope")
+	assert.False(t, found)
+}
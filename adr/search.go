@@ -0,0 +1,198 @@
+package adr
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+// titleMatchWeight is how much more a title match is worth than a body match.
+const titleMatchWeight = 10
+
+// SearchResult is a single ADR matching a search query.
+type SearchResult struct {
+	File
+	// Score ranks the result. higher is better
+	Score int
+	// Hits holds the matching lines, in document order
+	Hits []SearchHit
+}
+
+// SearchHit is a single line matching a search query.
+type SearchHit struct {
+	// Line is the 1-based line number within the file
+	Line int
+	// Text is the line's content
+	Text string
+	// Matches holds the [start, end) byte offsets of each matched term within Text, in order
+	Matches [][2]int
+}
+
+// SearchTerms splits a query into unique, lowercase search terms. quoted phrases are kept together.
+// only ascii letters are lowered, so match offsets stay true to the original text.
+func SearchTerms(query string) []string {
+	var terms []string
+
+	add := func(term string) {
+		if term != "" && !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+
+	for i, part := range strings.Split(query, `"`) {
+		// odd parts were inside quotes
+		if i%2 == 1 {
+			add(asciiLower(strings.TrimSpace(part)))
+
+			continue
+		}
+
+		for _, field := range strings.Fields(part) {
+			add(asciiLower(field))
+		}
+	}
+
+	return terms
+}
+
+// SearchArgTerms builds search terms from command-line arguments, as the shell split them. an argument holding
+// spaces was quoted, so it's a phrase. arguments holding double quotes are split like SearchTerms.
+// example: [kafka, "event bus"] becomes ["kafka", "event bus"].
+func SearchArgTerms(args []string) []string {
+	var terms []string
+
+	for _, arg := range args {
+		argTerms := SearchTerms(arg)
+		if !strings.Contains(arg, `"`) && len(argTerms) > 1 {
+			argTerms = []string{asciiLower(strings.TrimSpace(arg))}
+		}
+
+		for _, term := range argTerms {
+			if !slices.Contains(terms, term) {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return terms
+}
+
+// Search finds the files matching every term, ranked best-first. see SearchTerms and SearchArgTerms.
+// title matches are worth more than body matches, and more occurrences rank higher. ties are ordered by sequence.
+func Search(files []File, terms []string) ([]SearchResult, error) {
+	if len(terms) == 0 {
+		return nil, globals.ValidationError("query", "query is empty")
+	}
+
+	results := make([]SearchResult, 0, len(files))
+
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ADR: %w", err)
+		}
+
+		if result, ok := searchFile(file, content, terms); ok {
+			results = append(results, result)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Sequence, b.Sequence))
+	})
+
+	return results, nil
+}
+
+// searchFile scores a single file's title and body against terms. front matter isn't searched.
+// Returns false unless every term is found somewhere in the file.
+func searchFile(file File, content []byte, terms []string) (SearchResult, bool) {
+	result := SearchResult{File: file, Score: 0, Hits: nil}
+	found := make(map[string]bool, len(terms))
+
+	title := asciiLower(file.Title)
+	for _, term := range terms {
+		if count := strings.Count(title, term); count > 0 {
+			result.Score += count * titleMatchWeight
+			found[term] = true
+		}
+	}
+
+	// keep line numbers true to the file, even though front matter is skipped
+	body := StripFrontMatter(content)
+	lineNumber := bytes.Count(content[:len(content)-len(body)], []byte("\n"))
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		lineNumber++
+
+		text := scanner.Text()
+		lower := asciiLower(text)
+
+		matches := matchTerms(lower, terms)
+		if len(matches) == 0 {
+			continue
+		}
+
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				found[term] = true
+			}
+		}
+
+		result.Score += len(matches)
+		result.Hits = append(result.Hits, SearchHit{Line: lineNumber, Text: text, Matches: matches})
+	}
+
+	return result, len(found) == len(terms)
+}
+
+// matchTerms returns the byte offsets of every occurrence of terms in lower, which must already be asciiLower'd.
+// overlapping matches are dropped, keeping the earliest.
+func matchTerms(lower string, terms []string) [][2]int {
+	var matches [][2]int
+
+	for _, term := range terms {
+		for offset := 0; offset < len(lower); {
+			index := strings.Index(lower[offset:], term)
+			if index < 0 {
+				break
+			}
+
+			start := offset + index
+			matches = append(matches, [2]int{start, start + len(term)})
+			offset = start + len(term)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) })
+
+	// drop overlaps
+	kept := matches[:0]
+	for _, match := range matches {
+		if len(kept) > 0 && match[0] < kept[len(kept)-1][1] {
+			continue
+		}
+
+		kept = append(kept, match)
+	}
+
+	return kept
+}
+
+// asciiLower lowers ascii letters only. unlike strings.ToLower, it never changes byte offsets.
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+
+		return r
+	}, s)
+}
@@ -0,0 +1,146 @@
+package adr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"kafka", "event bus", "queue"}, SearchTerms(`Kafka "Event Bus" queue kafka`))
+	assert.Empty(t, SearchTerms(`  "" `))
+}
+
+func TestSearchArgTerms(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		args       []string
+		assertFunc func(t *testing.T, terms []string)
+	}{
+		{
+			name: "shell-quoted arguments are phrases",
+			args: []string{"Kafka", "Event Bus", "queue"},
+			assertFunc: func(t *testing.T, terms []string) {
+				assert.Equal(t, []string{"kafka", "event bus", "queue"}, terms)
+			},
+		},
+		{
+			name: "unquoted words are separate terms",
+			args: []string{"event", "bus"},
+			assertFunc: func(t *testing.T, terms []string) {
+				assert.Equal(t, []string{"event", "bus"}, terms)
+			},
+		},
+		{
+			name: "quotes inside an argument are kept",
+			args: []string{`kafka "event bus"`, "kafka"},
+			assertFunc: func(t *testing.T, terms []string) {
+				assert.Equal(t, []string{"kafka", "event bus"}, terms)
+			},
+		},
+		{
+			name: "blank arguments are skipped",
+			args: []string{" ", ""},
+			assertFunc: func(t *testing.T, terms []string) {
+				assert.Empty(t, terms)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.assertFunc(t, SearchArgTerms(test.args))
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"0001-use-kafka.md": "---\ntags:\n  - kafka\n---\n0001: Use Kafka\n---\n\n## Context\nwe need a queue\n",
+		"0002-queues.md":    "0002: Queues\n---\n\n## Context\nKafka or SQS? kafka.\n\n## Decision\nkafka\n",
+		"0003-unrelated.md": "0003: Unrelated\n---\n\n## Context\nnothing here\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	loaded, err := LoadDirectory(dir)
+	require.NoError(t, err)
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		query      string
+		assertFunc func(t *testing.T, results []SearchResult, err error)
+	}{
+		{
+			name:  "title matches rank first",
+			query: "kafka",
+			assertFunc: func(t *testing.T, results []SearchResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, results, 2)
+
+				assert.Equal(t, 1, results[0].Sequence)
+				assert.Equal(t, 2, results[1].Sequence)
+
+				// front matter isn't searched, but line numbers count it
+				require.Len(t, results[0].Hits, 1)
+				assert.Equal(t, 5, results[0].Hits[0].Line)
+				assert.Equal(t, "0001: Use Kafka", results[0].Hits[0].Text)
+				assert.Equal(t, [][2]int{{10, 15}}, results[0].Hits[0].Matches)
+
+				// every occurrence is marked
+				require.Len(t, results[1].Hits, 2)
+				assert.Equal(t, 5, results[1].Hits[0].Line)
+				assert.Equal(t, [][2]int{{0, 5}, {14, 19}}, results[1].Hits[0].Matches)
+			},
+		},
+		{
+			name:  "every term must match",
+			query: "kafka need",
+			assertFunc: func(t *testing.T, results []SearchResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, results, 1)
+				assert.Equal(t, 1, results[0].Sequence)
+			},
+		},
+		{
+			name:  "phrases match whole",
+			query: `"or sqs"`,
+			assertFunc: func(t *testing.T, results []SearchResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, results, 1)
+				assert.Equal(t, 2, results[0].Sequence)
+			},
+		},
+		{
+			name:  "no matches",
+			query: "postgres",
+			assertFunc: func(t *testing.T, results []SearchResult, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, results)
+			},
+		},
+		{
+			name:  "empty query",
+			query: " ",
+			assertFunc: func(t *testing.T, results []SearchResult, err error) {
+				require.Error(t, err)
+				assert.Nil(t, results)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := Search(loaded, SearchTerms(test.query))
+			test.assertFunc(t, results, err)
+		})
+	}
+}
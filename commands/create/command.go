@@ -2,18 +2,21 @@ package create
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"slices"
+	"time"
 
-	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/therealkevinard/adr-er/adr"
 	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
 	"github.com/therealkevinard/adr-er/render"
 	"github.com/therealkevinard/adr-er/theme"
 	"github.com/therealkevinard/adr-er/utils"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
 var _ commands.CliCommand = (*Command)(nil)
@@ -26,14 +29,20 @@ type Command struct {
 	outputStdOut bool
 	// the next integer sequence for the adrs in this directory
 	nextSequence int
+	// config holds the repository's conventions: default format and template, statuses, and required fields
+	config *config.Config
+	// in is where input is read from when the form doesn't run
+	in *os.File
 }
 
 // NewCommand is a constructor.
-func NewCommand(outputDir string, nextSequence int) *Command {
+func NewCommand(outputDir string, nextSequence int, cfg *config.Config) *Command {
 	cmd := &Command{
 		outputDir:    outputDir,
 		nextSequence: nextSequence,
 		outputStdOut: false,
+		config:       cfg,
+		in:           os.Stdin,
 	}
 	// set stdout flag if outputDir is one of the magic strings
 	if slices.Contains([]string{"", "-", "/"}, cmd.outputDir) {
@@ -43,23 +52,135 @@ func NewCommand(outputDir string, nextSequence int) *Command {
 	return cmd
 }
 
-//nolint:funlen // tui apps are long by nature
-func (n Command) Action(_ *cli.Context) error {
-	var err error
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "template",
+			Aliases: []string{"t"},
+			Usage:   "template to write the ADR with: default (Nygard-style), madr, or one of your own. overrides " + config.FileName,
+			Value:   render.TemplateIDDefault,
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "document format: markdown, asciidoc, rst, org, or html. overrides " + config.FileName,
+			Value:   string(render.DocumentFormatMarkdown),
+		},
+		&cli.StringFlag{
+			Name:  "template-dir",
+			Usage: "directory holding your own templates, named {name}.{format}.tpl. defaults to <adr-dir>/" + render.LocalTemplatesDir,
+		},
+		// input flags skip the form
+		&cli.StringFlag{
+			Name:  "title",
+			Usage: "the ADR's title. skips the form",
+		},
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "the ADR's context. skips the form",
+		},
+		&cli.StringFlag{
+			Name:  "decision",
+			Usage: "the ADR's decision. skips the form",
+		},
+		&cli.StringFlag{
+			Name:  "consequences",
+			Usage: "the ADR's consequences. skips the form",
+		},
+		&cli.StringFlag{
+			Name:  "status",
+			Usage: "the ADR's initial status. skips the form. defaults to the first initial status",
+		},
+		&cli.BoolFlag{
+			Name:  "editor",
+			Usage: "write the ADR in your editor instead of the form: the config's, $VISUAL, or $EDITOR. --title fills in the title",
+		},
+		&cli.StringFlag{
+			Name:  "from-file",
+			Usage: "json or yaml file describing the ADR, or - for stdin. skips the form. flags override its fields",
+		},
+	}
+}
+
+// Action runs the tui form for a new ADR, then writes the resulting document.
+// The template, chosen with --template or the repository config, decides which fields the form collects.
+// Without a terminal on stdin, or with input flags, the form is skipped: fields are read from flags, --from-file, or
+// stdin, and validated as the form would. invalid input is an error.
+func (n Command) Action(ctx *cli.Context) error {
+	// load the template up front, so an unknown one fails before the form.
+	// templates in the repo's own template directory take precedence over the built-in ones.
+	templateDir := ctx.String("template-dir")
+	if templateDir == "" && !n.outputStdOut {
+		templateDir = render.LocalTemplateDir(n.outputDir)
+	}
+
+	// flags take precedence over the repository config
+	formatName, templateID := n.config.Format, n.config.Template
+	if ctx.IsSet("format") {
+		formatName = ctx.String("format")
+	}
+
+	if ctx.IsSet("template") {
+		templateID = ctx.String("template")
+	}
+
+	format, err := render.FormatForName(formatName)
+	if err != nil {
+		return err
+	}
+
+	tpl, err := render.TemplateForFormat(templateID, format, templateDir)
+	if err != nil {
+		return fmt.Errorf("error finding template: %w", err)
+	}
 
-	// form values
-	confirmed := false
 	record := &adr.ADR{
-		Sequence:     n.nextSequence,
-		Title:        "",
-		Context:      "",
-		Decision:     "",
-		Status:       "",
-		Consequences: "",
+		Sequence:      n.nextSequence,
+		Title:         "",
+		Context:       "",
+		Decision:      "",
+		Status:        "",
+		Consequences:  "",
+		Created:       time.Now(),
+		StatusChanged: time.Time{},
+		Authors:       nil,
+		Deciders:      nil,
+		Tags:          nil,
+		Links:         nil,
+		StatusHistory: nil,
+
+		ConsideredOptions: nil,
+		ProsAndCons:       "",
+		Consulted:         nil,
+		Informed:          nil,
 	}
 
-	// run with error-or-cancel
-	{
+	// input comes from the editor, from flags, files or stdin, or from the form
+	switch {
+	case ctx.Bool("editor"):
+		confirmed, editErr := n.runEditor(ctx, record, tpl)
+		if editErr != nil {
+			return editErr
+		}
+
+		if !confirmed {
+			theme.ApplicationTheme().RenderCancelMessage()
+
+			return nil
+		}
+	case !n.isInteractive(ctx):
+		// without the form, input is validated and applied directly
+		input, inputErr := n.readInput(ctx)
+		if inputErr != nil {
+			return inputErr
+		}
+
+		if err = applyInput(record, input, n.config, FieldsForTemplate(tpl)); err != nil {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+	default:
+		// run with error-or-cancel
 		var confirmText string
 		if n.outputStdOut {
 			confirmText = "this will flush to stderr"
@@ -68,53 +189,15 @@ func (n Command) Action(_ *cli.Context) error {
 			confirmText = fmt.Sprintf("this will create next sequence number %d \nin %s", n.nextSequence, displayPath)
 		}
 
-		//nolint:mnd // magic numbers are expected here
-		form := huh.NewForm(
-			huh.NewGroup(
-				// title
-				huh.NewInput().
-					Value(&record.Title).
-					Title("Title").
-					Description("name your decision").
-					CharLimit(128).
-					Inline(false).
-					Validate(commands.StrLenValidator("title", 3, 128)),
-				// context
-				huh.NewText().
-					Value(&record.Context).
-					Title("Context").
-					Description("add relevant context"),
-				// decision
-				huh.NewText().
-					Value(&record.Decision).
-					Title("Decision").
-					Description("what did you folks decide to do"),
-				// consequences
-				huh.NewText().
-					Value(&record.Consequences).
-					Title("Consequences").
-					Description("what are the consequences of this decision?"),
-				// status
-				huh.NewSelect[string]().
-					Value(&record.Status).
-					Title("Status").
-					OptionsFunc(n.statusOptions, nil).
-					Description("what's the current status?"),
-
-				// confirmation
-				huh.NewConfirm().
-					Value(&confirmed).
-					Title("feeling good about this one?").
-					Description(confirmText),
-			).Title("The Decision"),
-		).WithTheme(theme.ApplicationTheme().Theme)
-
-		if err = form.Run(); err != nil {
-			return fmt.Errorf("error running form: %w", err)
+		confirmed, err := RunForm(record, n.config, FieldsForTemplate(tpl), confirmText)
+		if err != nil {
+			return err
 		}
 
 		if !confirmed {
 			theme.ApplicationTheme().RenderCancelMessage()
+
+			return nil
 		}
 	}
 
@@ -128,16 +211,8 @@ func (n Command) Action(_ *cli.Context) error {
 		// if writing to stdout, this is the ADR string; for file output, it's a friendly status message
 		var finalMsg string
 
-		// run load-compile-write under a spinner
-		_ = spinner.New().Title("saving the file").Action(func() {
-			// load the template
-			tpl, tplErr := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
-			if tplErr != nil {
-				outputErr = fmt.Errorf("error finding template: %w", tplErr)
-
-				return
-			}
-
+		// run compile-write under a spinner, if there's a terminal to show it
+		write := func() {
 			// build the document
 			document, buildErr := record.BuildDocument(tpl)
 			if buildErr != nil {
@@ -161,7 +236,13 @@ func (n Command) Action(_ *cli.Context) error {
 			} else {
 				finalMsg = string(document.Content)
 			}
-		}).Run()
+		}
+
+		if term.IsTerminal(int(n.in.Fd())) {
+			_ = spinner.New().Title("saving the file").Action(write).Run()
+		} else {
+			write()
+		}
 
 		if outputErr != nil {
 			return fmt.Errorf("error writing adr document: %w", outputErr)
@@ -178,15 +259,3 @@ func (n Command) Action(_ *cli.Context) error {
 
 	return nil
 }
-
-// statusOptions returns valid options for status selection.
-// TODO: this is overkill rn. either deprecate or build against it.
-func (n Command) statusOptions() []huh.Option[string] {
-	return []huh.Option[string]{
-		huh.NewOption("proposed", "proposed"),
-		huh.NewOption("accepted", "accepted"),
-		huh.NewOption("rejected", "rejected"),
-		huh.NewOption("deprecated", "deprecated"),
-		huh.NewOption("superceded", "superceded"),
-	}
-}
@@ -0,0 +1,149 @@
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/urfave/cli/v2"
+)
+
+// placeholderTitle fills the draft's title when --title isn't given. it's meant to be replaced in the editor.
+const placeholderTitle = "Untitled Decision"
+
+// fallbackEditor is opened when neither the config, $VISUAL, nor $EDITOR name one.
+const fallbackEditor = "vi"
+
+// editorCommand returns the command that opens a file for editing, split into its arguments: the config's editor,
+// then $VISUAL, then $EDITOR, then vi.
+func editorCommand(cfg *config.Config) []string {
+	for _, editor := range []string{cfg.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if fields := strings.Fields(editor); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	return []string{fallbackEditor}
+}
+
+// runEditor has the user write the ADR in their editor, filling record from the result.
+// the template is rendered with only the title filled in (from --title, or a placeholder) to a temp file, which is
+// opened in the editor. once it closes, the file is parsed back and validated as the form would.
+// Returns false if the draft was saved unchanged. a draft that can't be used is kept, and its path is in the error, so
+// no writing is lost.
+func (n Command) runEditor(ctx *cli.Context, record *adr.ADR, tpl *render.ParsedTemplateFile) (bool, error) {
+	lifecycle := n.config.Lifecycle()
+
+	// the draft starts in the first initial status. only the body is written by the user.
+	draft := *record
+
+	draft.Title = strings.TrimSpace(ctx.String("title"))
+	if draft.Title == "" {
+		draft.Title = placeholderTitle
+	}
+
+	if initial := lifecycle.InitialStatuses(); len(initial) > 0 {
+		draft.Status = initial[0]
+	}
+
+	document, err := draft.BuildDocument(tpl)
+	if err != nil {
+		return false, fmt.Errorf("error rendering draft: %w", err)
+	}
+
+	// the temp file keeps the format's extension, so editors highlight it
+	file, err := os.CreateTemp("", "adr-*."+tpl.Format.Extension())
+	if err != nil {
+		return false, fmt.Errorf("error creating draft: %w", err)
+	}
+
+	path := file.Name()
+
+	_, err = file.Write(document.Content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("error writing draft: %w", err)
+	}
+
+	// from here on, the draft is only removed once it's no longer needed
+	edited, err := editFile(editorCommand(n.config), path)
+	if err != nil {
+		return false, fmt.Errorf("%w. your draft is kept at %s", err, path)
+	}
+
+	if bytes.Equal(edited, document.Content) {
+		_ = os.Remove(path)
+
+		return false, nil
+	}
+
+	if err = applyDraft(record, document.Filename(), edited, n.config, FieldsForTemplate(tpl)); err != nil {
+		return false, fmt.Errorf("invalid ADR: %w. your draft is kept at %s", err, path)
+	}
+
+	_ = os.Remove(path)
+
+	return true, nil
+}
+
+// editFile opens path in the editor, waits for it to close, and returns the file's content.
+func editFile(editor []string, path string) ([]byte, error) {
+	//nolint:gosec // running the user's own editor is the point
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running editor %s: %w", editor[0], err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading draft: %w", err)
+	}
+
+	return content, nil
+}
+
+// applyDraft parses an edited draft, named like the ADR it becomes, and fills record from it.
+// the draft is validated as the form's input would be, so the status must be one of the initial statuses.
+// unrecognized sections are refused, since their content would be lost, as are leftover placeholder titles.
+func applyDraft(record *adr.ADR, filename string, content []byte, cfg *config.Config, fields FormFields) error {
+	parsed, warnings, err := adr.Parse(filename, content)
+	if err != nil {
+		return fmt.Errorf("error parsing draft: %w", err)
+	}
+
+	for _, warning := range warnings {
+		if warning.Kind == adr.WarningUnrecognized {
+			return globals.ValidationError(warning.Field, warning.Reason)
+		}
+	}
+
+	if parsed.Title == placeholderTitle {
+		return globals.ValidationError("title", "still the placeholder")
+	}
+
+	return applyInput(record, Input{
+		Title:             parsed.Title,
+		Context:           parsed.Context,
+		Decision:          parsed.Decision,
+		Consequences:      parsed.Consequences,
+		Status:            parsed.Status,
+		Authors:           parsed.Authors,
+		Deciders:          parsed.Deciders,
+		Tags:              parsed.Tags,
+		ConsideredOptions: parsed.ConsideredOptions,
+		ProsAndCons:       parsed.ProsAndCons,
+		Consulted:         parsed.Consulted,
+		Informed:          parsed.Informed,
+	}, cfg, fields)
+}
@@ -0,0 +1,207 @@
+package create
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/urfave/cli/v2"
+)
+
+func TestEditorCommand(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		configured string
+		visual     string
+		editor     string
+		assertFunc func(t *testing.T, command []string)
+	}{
+		{
+			name:       "config first",
+			configured: "code --wait",
+			visual:     "nano",
+			editor:     "emacs",
+			assertFunc: func(t *testing.T, command []string) {
+				assert.Equal(t, []string{"code", "--wait"}, command)
+			},
+		},
+		{
+			name:   "then $VISUAL",
+			visual: "nano",
+			editor: "emacs",
+			assertFunc: func(t *testing.T, command []string) {
+				assert.Equal(t, []string{"nano"}, command)
+			},
+		},
+		{
+			name:   "then $EDITOR",
+			visual: " ",
+			editor: "emacs -nw",
+			assertFunc: func(t *testing.T, command []string) {
+				assert.Equal(t, []string{"emacs", "-nw"}, command)
+			},
+		},
+		{
+			name: "then vi",
+			assertFunc: func(t *testing.T, command []string) {
+				assert.Equal(t, []string{fallbackEditor}, command)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+
+			cfg := config.Default()
+			cfg.Editor = tt.configured
+
+			tt.assertFunc(t, editorCommand(cfg))
+		})
+	}
+}
+
+func TestApplyDraft(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		content    string
+		assertFunc func(t *testing.T, record *adr.ADR, err error)
+	}{
+		{
+			name:    "written draft",
+			content: "0001: Use Postgres\n---\n\n## Status: proposed\n\n## Context\nwe need a database\n",
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Use Postgres", record.Title)
+				assert.Equal(t, "we need a database", record.Context)
+				assert.Equal(t, adr.StatusProposed, record.Status)
+			},
+		},
+		{
+			name:    "leftover placeholder title",
+			content: "0001: " + placeholderTitle + "\n---\n\n## Status: proposed\n\n## Context\nwe need a database\n",
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "title", validationErr.Field)
+				assert.Empty(t, record.Title)
+			},
+		},
+		{
+			name:    "unknown section",
+			content: "0001: Use Postgres\n---\n\n## Status: proposed\n\n## Notes\nthis would be lost\n",
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "notes", validationErr.Field)
+				assert.Empty(t, record.Title)
+			},
+		},
+		{
+			name:    "status that isn't an initial status",
+			content: "0001: Use Postgres\n---\n\n## Status: superseded\n",
+			assertFunc: func(t *testing.T, _ *adr.ADR, err error) {
+				var transitionErr globals.StatusTransitionError
+				require.ErrorAs(t, err, &transitionErr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &adr.ADR{}
+			err := applyDraft(record, "0001-draft.md", []byte(tt.content), config.Default(), FieldsNygard)
+			tt.assertFunc(t, record, err)
+		})
+	}
+}
+
+func TestRunEditor(t *testing.T) {
+	tpl, err := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
+	require.NoError(t, err)
+
+	// editor scripts stand in for the user, receiving the draft's path
+	scripts := t.TempDir()
+	writeScript := func(name, body string) string {
+		path := filepath.Join(scripts, name)
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700)) //nolint:gosec // it's a script
+
+		return path
+	}
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		editor     string
+		assertFunc func(t *testing.T, record *adr.ADR, written bool, err error)
+	}{
+		{
+			name:   "written draft",
+			editor: writeScript("write.sh", `sed -i "s/`+placeholderTitle+`/Use Postgres/" "$1"`),
+			assertFunc: func(t *testing.T, record *adr.ADR, written bool, err error) {
+				require.NoError(t, err)
+				assert.True(t, written)
+				assert.Equal(t, "Use Postgres", record.Title)
+				assert.Equal(t, adr.StatusProposed, record.Status)
+			},
+		},
+		{
+			name:   "unchanged draft cancels",
+			editor: writeScript("unchanged.sh", "exit 0"),
+			assertFunc: func(t *testing.T, record *adr.ADR, written bool, err error) {
+				require.NoError(t, err)
+				assert.False(t, written)
+				assert.Empty(t, record.Title)
+			},
+		},
+		{
+			name:   "failed editor keeps the draft",
+			editor: writeScript("fail.sh", "exit 1"),
+			assertFunc: func(t *testing.T, _ *adr.ADR, written bool, err error) {
+				require.Error(t, err)
+				assert.False(t, written)
+				assert.Contains(t, err.Error(), "your draft is kept at")
+			},
+		},
+		{
+			name:   "invalid draft is kept",
+			editor: writeScript("placeholder.sh", `echo "more context" >> "$1"`),
+			assertFunc: func(t *testing.T, _ *adr.ADR, written bool, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.False(t, written)
+				assert.Contains(t, err.Error(), "your draft is kept at")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// drafts are written to the temp dir
+			t.Setenv("TMPDIR", t.TempDir())
+
+			cfg := config.Default()
+			cfg.Editor = tt.editor
+
+			ctx := cli.NewContext(cli.NewApp(), flag.NewFlagSet("create", flag.ContinueOnError), nil)
+			record := &adr.ADR{Sequence: 1}
+
+			written, err := NewCommand("-", 1, cfg).runEditor(ctx, record, tpl)
+			tt.assertFunc(t, record, written, err)
+
+			// only failures keep the draft
+			drafts, _ := filepath.Glob(filepath.Join(os.Getenv("TMPDIR"), "adr-*"))
+			assert.Equal(t, err != nil, len(drafts) == 1)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// FormFields picks the set of fields the form collects, matching the template the ADR will be rendered with.
+type FormFields int
+
+// FormFields enum.
+const (
+	// FieldsNygard collects the classic context, decision, and consequences
+	FieldsNygard FormFields = iota
+	// FieldsMADR adds MADR's considered options, pros and cons, and its consulted and informed parties
+	FieldsMADR
+)
+
+// madrFieldNames are the ADR fields only the MADR form collects.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var madrFieldNames = []string{".ConsideredOptions", ".ProsAndCons", ".Consulted", ".Informed"}
+
+// FieldsForTemplate returns the form fields suited to a template.
+// The MADR fields are collected for the madr template, and for any custom template that uses them.
+func FieldsForTemplate(tpl *render.ParsedTemplateFile) FormFields {
+	if tpl.ID == render.TemplateIDMADR {
+		return FieldsMADR
+	}
+
+	for _, name := range madrFieldNames {
+		if bytes.Contains(tpl.Content, []byte(name)) {
+			return FieldsMADR
+		}
+	}
+
+	return FieldsNygard
+}
+
+// RunForm runs the tui form for authoring an ADR, writing the input into record.
+// Any values already set on record pre-fill the form. The chosen status is applied through the config's lifecycle, and
+// the config's required fields can't be left blank. fields picks which fields are collected. confirmText describes
+// what will happen on confirmation.
+// Returns false if the user declined to confirm, in which case record should be discarded.
+//
+//nolint:funlen // tui apps are long by nature
+func RunForm(record *adr.ADR, cfg *config.Config, fields FormFields, confirmText string) (bool, error) {
+	confirmed := false
+	lifecycle := cfg.Lifecycle()
+
+	// status is applied through the lifecycle after the form runs
+	status := record.Status
+	record.Status = ""
+
+	// list-valued metadata is collected as comma-separated text
+	authors := strings.Join(record.Authors, ", ")
+	deciders := strings.Join(record.Deciders, ", ")
+	consulted := strings.Join(record.Consulted, ", ")
+	informed := strings.Join(record.Informed, ", ")
+	tags := strings.Join(record.Tags, ", ")
+
+	// options are collected one per line
+	options := strings.Join(record.ConsideredOptions, "\n")
+
+	// labels follow the template's vocabulary
+	contextTitle, decisionTitle, decisionDescription := "Context", "Decision", "what did you folks decide to do"
+	if fields == FieldsMADR {
+		contextTitle = "Context and Problem Statement"
+		decisionTitle = "Decision Outcome"
+		decisionDescription = `eg: Chosen option: "option 1", because ...`
+	}
+
+	//nolint:mnd // magic numbers are expected here
+	formFields := []huh.Field{
+		// title
+		huh.NewInput().
+			Value(&record.Title).
+			Title("Title").
+			Description("name your decision").
+			CharLimit(128).
+			Inline(false).
+			Validate(commands.StrLenValidator("title", 3, 128)),
+		// context
+		textField(cfg, config.FieldContext, &record.Context).
+			Title(contextTitle).
+			Description("add relevant context"),
+	}
+
+	if fields == FieldsMADR {
+		formFields = append(formFields,
+			textField(cfg, config.FieldConsideredOptions, &options).
+				Title("Considered Options").
+				Description("what options were weighed? (one per line)"),
+		)
+	}
+
+	formFields = append(formFields,
+		// decision
+		textField(cfg, config.FieldDecision, &record.Decision).
+			Title(decisionTitle).
+			Description(decisionDescription),
+		// consequences
+		textField(cfg, config.FieldConsequences, &record.Consequences).
+			Title("Consequences").
+			Description("what are the consequences of this decision?"),
+	)
+
+	if fields == FieldsMADR {
+		formFields = append(formFields,
+			textField(cfg, config.FieldProsAndCons, &record.ProsAndCons).
+				Title("Pros and Cons of the Options").
+				Description("the good and bad of each option"),
+		)
+	}
+
+	formFields = append(formFields,
+		// status
+		huh.NewSelect[string]().
+			Value(&status).
+			Title("Status").
+			Options(statusOptions(lifecycle)...).
+			Description("what's the current status?"),
+		// metadata
+		huh.NewInput().
+			Value(&authors).
+			Validate(requiredValidator(cfg, config.FieldAuthors)).
+			Title("Authors").
+			Description("who wrote this? (comma-separated)"),
+		huh.NewInput().
+			Value(&deciders).
+			Validate(requiredValidator(cfg, config.FieldDeciders)).
+			Title("Deciders").
+			Description("who made the call? (comma-separated)"),
+	)
+
+	if fields == FieldsMADR {
+		formFields = append(formFields,
+			huh.NewInput().
+				Value(&consulted).
+				Validate(requiredValidator(cfg, config.FieldConsulted)).
+				Title("Consulted").
+				Description("whose opinions were sought? (comma-separated)"),
+			huh.NewInput().
+				Value(&informed).
+				Validate(requiredValidator(cfg, config.FieldInformed)).
+				Title("Informed").
+				Description("who is kept up to date? (comma-separated)"),
+		)
+	}
+
+	formFields = append(formFields,
+		huh.NewInput().
+			Value(&tags).
+			Validate(requiredValidator(cfg, config.FieldTags)).
+			Title("Tags").
+			Description("labels for finding this later (comma-separated)"),
+
+		// confirmation
+		huh.NewConfirm().
+			Value(&confirmed).
+			Title("feeling good about this one?").
+			Description(confirmText),
+	)
+
+	form := huh.NewForm(
+		huh.NewGroup(formFields...).Title("The Decision"),
+	).WithTheme(theme.ApplicationTheme().Theme)
+
+	if err := form.Run(); err != nil {
+		return false, fmt.Errorf("error running form: %w", err)
+	}
+
+	if !confirmed {
+		return false, nil
+	}
+
+	if err := record.TransitionStatus(lifecycle, status, time.Now()); err != nil {
+		return false, fmt.Errorf("error setting status: %w", err)
+	}
+
+	record.Authors = utils.SplitList(authors)
+	record.Deciders = utils.SplitList(deciders)
+	record.Tags = utils.SplitList(tags)
+
+	if fields == FieldsMADR {
+		record.Consulted = utils.SplitList(consulted)
+		record.Informed = utils.SplitList(informed)
+		record.ConsideredOptions = adr.ListItems(options)
+	}
+
+	return true, nil
+}
+
+// textField builds a long text field for an ADR field, validated against the config's required fields.
+// ctrl+e opens the configured editor, falling back to $EDITOR.
+func textField(cfg *config.Config, field string, value *string) *huh.Text {
+	text := huh.NewText().
+		Value(value).
+		Validate(requiredValidator(cfg, field))
+
+	if editor := strings.Fields(cfg.Editor); len(editor) > 0 {
+		text = text.Editor(editor...)
+	}
+
+	return text
+}
+
+// requiredValidator returns a func that rejects blank values, if the config requires field.
+func requiredValidator(cfg *config.Config, field string) func(string) error {
+	if !cfg.IsRequired(field) {
+		return func(string) error { return nil }
+	}
+
+	return commands.RequiredValidator(field)
+}
+
+// statusOptions lists the lifecycle's initial statuses as select options, each in its display color.
+func statusOptions(lifecycle *adr.Lifecycle) []huh.Option[string] {
+	statuses := lifecycle.InitialStatuses()
+
+	options := make([]huh.Option[string], 0, len(statuses))
+	for _, status := range statuses {
+		options = append(options, huh.NewOption(theme.StatusStyle(lifecycle.Color(status)).Render(status), status))
+	}
+
+	return options
+}
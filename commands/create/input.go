@@ -0,0 +1,193 @@
+package create
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// inputFlags are the flags that set a single field of a new ADR. any of them skips the form.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var inputFlags = []string{"title", "context", "decision", "consequences", "status"}
+
+// Input describes a new ADR as data, for creating it without the form. it's read as json or yaml, and every field is
+// optional. keys follow the export's naming, eg: "considered_options".
+type Input struct {
+	Title        string `json:"title" yaml:"title"`
+	Context      string `json:"context" yaml:"context"`
+	Decision     string `json:"decision" yaml:"decision"`
+	Consequences string `json:"consequences" yaml:"consequences"`
+	// Status must be one of the lifecycle's initial statuses. empty for the first of them.
+	Status string `json:"status" yaml:"status"`
+
+	Authors  []string `json:"authors" yaml:"authors"`
+	Deciders []string `json:"deciders" yaml:"deciders"`
+	Tags     []string `json:"tags" yaml:"tags"`
+
+	// MADR fields. they're ignored by templates that don't collect them.
+	ConsideredOptions []string `json:"considered_options" yaml:"considered_options"`
+	ProsAndCons       string   `json:"pros_and_cons" yaml:"pros_and_cons"`
+	Consulted         []string `json:"consulted" yaml:"consulted"`
+	Informed          []string `json:"informed" yaml:"informed"`
+}
+
+// ParseInput reads an Input from json or yaml content. empty content is an empty Input.
+// unknown keys are an error, so a typo can't quietly drop a section.
+func ParseInput(content []byte) (Input, error) {
+	var input Input
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		return Input{}, fmt.Errorf("error parsing input: %w", err)
+	}
+
+	return input, nil
+}
+
+// isInteractive reports whether the form should run: only on a terminal, and only when no input was given by flags.
+func (n Command) isInteractive(ctx *cli.Context) bool {
+	return !hasInputFlags(ctx) && term.IsTerminal(int(n.in.Fd()))
+}
+
+// hasInputFlags reports whether any of the new ADR's input was given by flags, including --from-file.
+func hasInputFlags(ctx *cli.Context) bool {
+	for _, name := range append([]string{"from-file"}, inputFlags...) {
+		if ctx.IsSet(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readInput collects the new ADR's input without the form: from --from-file ("-" for stdin), or from stdin when it
+// isn't a terminal and no other input was given. flags override the file's fields.
+// stdin isn't read otherwise, so flags alone never wait on an open stdin, eg: in CI.
+func (n Command) readInput(ctx *cli.Context) (Input, error) {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch path := ctx.String("from-file"); {
+	case path == "-", !hasInputFlags(ctx) && !term.IsTerminal(int(n.in.Fd())):
+		content, err = io.ReadAll(n.in)
+	case path != "":
+		content, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return Input{}, fmt.Errorf("error reading input: %w", err)
+	}
+
+	input, err := ParseInput(content)
+	if err != nil {
+		return Input{}, err
+	}
+
+	for name, field := range map[string]*string{
+		"title":        &input.Title,
+		"context":      &input.Context,
+		"decision":     &input.Decision,
+		"consequences": &input.Consequences,
+		"status":       &input.Status,
+	} {
+		if ctx.IsSet(name) {
+			*field = ctx.String(name)
+		}
+	}
+
+	return input, nil
+}
+
+// applyInput validates input as the form would, then fills record from it.
+// fields picks which fields are collected: MADR fields are ignored by other templates. the status is applied through
+// the config's lifecycle, defaulting to its first initial status.
+func applyInput(record *adr.ADR, input Input, cfg *config.Config, fields FormFields) error {
+	if err := validateInput(input, cfg, fields); err != nil {
+		return err
+	}
+
+	record.Title = strings.TrimSpace(input.Title)
+	record.Context = input.Context
+	record.Decision = input.Decision
+	record.Consequences = input.Consequences
+	record.Authors = input.Authors
+	record.Deciders = input.Deciders
+	record.Tags = input.Tags
+
+	if fields == FieldsMADR {
+		record.ConsideredOptions = input.ConsideredOptions
+		record.ProsAndCons = input.ProsAndCons
+		record.Consulted = input.Consulted
+		record.Informed = input.Informed
+	}
+
+	lifecycle := cfg.Lifecycle()
+
+	status := input.Status
+	if status == "" && len(lifecycle.InitialStatuses()) > 0 {
+		status = lifecycle.InitialStatuses()[0]
+	}
+
+	if err := record.TransitionStatus(lifecycle, status, time.Now()); err != nil {
+		return fmt.Errorf("error setting status: %w", err)
+	}
+
+	return nil
+}
+
+// fieldValue pairs a form field with its collected value, for validation.
+type fieldValue struct {
+	field string
+	value string
+}
+
+// validateInput applies the form's validation to input, returning the first problem found.
+func validateInput(input Input, cfg *config.Config, fields FormFields) error {
+	//nolint:mnd // same limits as the form's title field
+	if err := commands.StrLenValidator("title", 3, 128)(strings.TrimSpace(input.Title)); err != nil {
+		return err
+	}
+
+	// list fields are validated as the form collects them: joined into text
+	values := []fieldValue{
+		{field: config.FieldContext, value: input.Context},
+		{field: config.FieldDecision, value: input.Decision},
+		{field: config.FieldConsequences, value: input.Consequences},
+		{field: config.FieldAuthors, value: strings.Join(input.Authors, ", ")},
+		{field: config.FieldDeciders, value: strings.Join(input.Deciders, ", ")},
+		{field: config.FieldTags, value: strings.Join(input.Tags, ", ")},
+	}
+
+	if fields == FieldsMADR {
+		values = append(values,
+			fieldValue{field: config.FieldConsideredOptions, value: strings.Join(input.ConsideredOptions, "\n")},
+			fieldValue{field: config.FieldProsAndCons, value: input.ProsAndCons},
+			fieldValue{field: config.FieldConsulted, value: strings.Join(input.Consulted, ", ")},
+			fieldValue{field: config.FieldInformed, value: strings.Join(input.Informed, ", ")},
+		)
+	}
+
+	for _, v := range values {
+		if err := requiredValidator(cfg, v.field)(v.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
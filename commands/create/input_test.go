@@ -0,0 +1,238 @@
+package create
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestParseInput(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		content    string
+		assertFunc func(t *testing.T, input Input, err error)
+	}{
+		{
+			name:    "yaml",
+			content: "title: Use Postgres\nconsidered_options: [postgres, mysql]\npros_and_cons: fast\n",
+			assertFunc: func(t *testing.T, input Input, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Use Postgres", input.Title)
+				assert.Equal(t, []string{"postgres", "mysql"}, input.ConsideredOptions)
+				assert.Equal(t, "fast", input.ProsAndCons)
+			},
+		},
+		{
+			name:    "json",
+			content: `{"title": "Use Postgres", "authors": ["jane"]}`,
+			assertFunc: func(t *testing.T, input Input, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Use Postgres", input.Title)
+				assert.Equal(t, []string{"jane"}, input.Authors)
+			},
+		},
+		{
+			name:    "empty content is an empty input",
+			content: "",
+			assertFunc: func(t *testing.T, input Input, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, Input{}, input)
+			},
+		},
+		{
+			name:    "unknown keys are an error",
+			content: "title: Use Postgres\nconsiderd_options: [postgres]\n",
+			assertFunc: func(t *testing.T, _ Input, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "considerd_options")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := ParseInput([]byte(tt.content))
+			tt.assertFunc(t, input, err)
+		})
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	requiring := func(fields ...string) *config.Config {
+		cfg := config.Default()
+		cfg.Required = fields
+
+		return cfg
+	}
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		input      Input
+		cfg        *config.Config
+		fields     FormFields
+		assertFunc func(t *testing.T, err error)
+	}{
+		{
+			name:   "only the title is required by default",
+			input:  Input{Title: "Use Postgres"},
+			cfg:    config.Default(),
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "short title",
+			input:  Input{Title: "  Go  "},
+			cfg:    config.Default(),
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "title", validationErr.Field)
+			},
+		},
+		{
+			name:   "required field left blank",
+			input:  Input{Title: "Use Postgres", Context: "we need a database", Consequences: " \n"},
+			cfg:    requiring(config.FieldConsequences),
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, config.FieldConsequences, validationErr.Field)
+			},
+		},
+		{
+			name:   "required list field left blank",
+			input:  Input{Title: "Use Postgres"},
+			cfg:    requiring(config.FieldTags),
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, config.FieldTags, validationErr.Field)
+			},
+		},
+		{
+			name:   "required MADR fields are ignored for the default template",
+			input:  Input{Title: "Use Postgres"},
+			cfg:    requiring(config.FieldConsideredOptions),
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "required MADR fields are checked for MADR",
+			input:  Input{Title: "Use Postgres"},
+			cfg:    requiring(config.FieldConsideredOptions),
+			fields: FieldsMADR,
+			assertFunc: func(t *testing.T, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, config.FieldConsideredOptions, validationErr.Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertFunc(t, validateInput(tt.input, tt.cfg, tt.fields))
+		})
+	}
+}
+
+func TestApplyInput(t *testing.T) {
+	madrInput := Input{
+		Title:             " Use Postgres ",
+		Context:           "we need a database",
+		ConsideredOptions: []string{"postgres", "mysql"},
+		ProsAndCons:       "fast",
+		Consulted:         []string{"dba"},
+		Informed:          []string{"everyone"},
+	}
+
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		input      Input
+		fields     FormFields
+		assertFunc func(t *testing.T, record *adr.ADR, err error)
+	}{
+		{
+			name:   "defaults to the first initial status",
+			input:  madrInput,
+			fields: FieldsMADR,
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Use Postgres", record.Title)
+				assert.Equal(t, "we need a database", record.Context)
+				assert.Equal(t, adr.StatusProposed, record.Status)
+				assert.Equal(t, []string{"postgres", "mysql"}, record.ConsideredOptions)
+				assert.Equal(t, "fast", record.ProsAndCons)
+				assert.Equal(t, []string{"dba"}, record.Consulted)
+				assert.Equal(t, []string{"everyone"}, record.Informed)
+			},
+		},
+		{
+			name:   "MADR fields are ignored for the default template",
+			input:  madrInput,
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "Use Postgres", record.Title)
+				assert.Empty(t, record.ConsideredOptions)
+				assert.Empty(t, record.ProsAndCons)
+				assert.Empty(t, record.Consulted)
+				assert.Empty(t, record.Informed)
+			},
+		},
+		{
+			name:   "status that isn't an initial status",
+			input:  Input{Title: "Use Postgres", Status: adr.StatusDeprecated},
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				var transitionErr globals.StatusTransitionError
+				require.ErrorAs(t, err, &transitionErr)
+				assert.Equal(t, adr.StatusDeprecated, transitionErr.To)
+				assert.Empty(t, record.Status)
+			},
+		},
+		{
+			name:   "unknown status",
+			input:  Input{Title: "Use Postgres", Status: "pending"},
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				var validationErr globals.InputValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "status", validationErr.Field)
+				assert.Empty(t, record.Status)
+			},
+		},
+		{
+			name:   "invalid input leaves the record alone",
+			input:  Input{Title: "Go", Context: "we need a database"},
+			fields: FieldsNygard,
+			assertFunc: func(t *testing.T, record *adr.ADR, err error) {
+				require.Error(t, err)
+				assert.Empty(t, record.Title)
+				assert.Empty(t, record.Context)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &adr.ADR{}
+			err := applyInput(record, tt.input, config.Default(), tt.fields)
+			tt.assertFunc(t, record, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for exporting the decision log as data.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle normalizes statuses
+	lifecycle *adr.Lifecycle
+	// out is where the export is written, unless --out is given
+	out io.Writer
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+		out:       os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "output format: json or yaml",
+			Value:   adr.ExportFormatJSON,
+		},
+		&cli.StringFlag{
+			Name:    "out",
+			Aliases: []string{"o"},
+			Usage:   "file to write the export to. defaults to stdout",
+		},
+	}
+}
+
+// Action writes every ADR as a single, versioned json or yaml document.
+func (c *Command) Action(ctx *cli.Context) error {
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	export, err := adr.NewExport(files, c.lifecycle, func(path string) string {
+		displayPath, _ := utils.DisplayShortpath(path)
+
+		return displayPath
+	})
+	if err != nil {
+		return fmt.Errorf("error exporting ADRs: %w", err)
+	}
+
+	encoded, err := export.Encode(ctx.String("format"))
+	if err != nil {
+		return fmt.Errorf("error exporting ADRs: %w", err)
+	}
+
+	if target := ctx.String("out"); target != "" {
+		//nolint:mnd,gosec // not magic. the export is meant to be read
+		if err = os.WriteFile(target, encoded, 0o644); err != nil {
+			return fmt.Errorf("error writing export: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err = c.out.Write(encoded); err != nil {
+		return fmt.Errorf("error writing export: %w", err)
+	}
+
+	return nil
+}
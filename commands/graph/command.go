@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for exporting the ADR relationship graph.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle normalizes statuses for node styling
+	lifecycle *adr.Lifecycle
+	// out is where the graph is written
+	out io.Writer
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+		out:       os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "output format: dot or mermaid",
+			Value:   string(render.GraphFormatMermaid),
+		},
+	}
+}
+
+// Action writes every ADR and the links between them as a graph, in the requested format.
+func (c *Command) Action(ctx *cli.Context) error {
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	graph := adr.BuildGraph(files, c.lifecycle)
+
+	rendered, err := graph.Render(render.GraphFormat(ctx.String("format")))
+	if err != nil {
+		return fmt.Errorf("error rendering graph: %w", err)
+	}
+
+	if _, err = io.WriteString(c.out, rendered); err != nil {
+		return fmt.Errorf("error writing graph: %w", err)
+	}
+
+	return nil
+}
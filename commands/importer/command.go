@@ -0,0 +1,321 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for importing an adr-tools repository.
+type Command struct {
+	// lifecycle normalizes statuses for display, and names superseded ones
+	lifecycle *adr.Lifecycle
+	// out is where the preview table is written
+	out *os.File
+}
+
+// NewCommand is a constructor.
+func NewCommand(cfg *config.Config) *Command {
+	return &Command{
+		lifecycle: cfg.Lifecycle(),
+		out:       os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "rewrite",
+			Usage: "rewrite the ADRs into adr-er's format and naming. without it, the import is only previewed",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "with --rewrite, write the converted ADRs to this directory, leaving the originals untouched",
+		},
+	}
+}
+
+// imported is an adr-tools ADR, along with where it came from and where it's headed.
+type imported struct {
+	file adr.File
+	// filename is the adr-er filename for the ADR
+	filename string
+}
+
+// Action reads the adr-tools ADRs at the path argument and previews them as adr-er sees them.
+// the path may be an adr-tools project root, holding a `.adr-dir` file or `doc/adr`, or the ADR directory itself.
+// with --rewrite, each ADR is rendered through adr-er's default template, in place or to --out.
+func (c *Command) Action(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return globals.ValidationError("arguments", "expected <path>")
+	}
+
+	sourceDir := utils.LocateADRToolsDirectory(ctx.Args().First())
+
+	records, err := loadADRTools(sourceDir)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return globals.ValidationError("path", fmt.Sprintf("no adr-tools ADRs found in %s", sourceDir))
+	}
+
+	// the new names are needed up front, so links between ADRs point at them
+	relink(records, c.lifecycle)
+
+	if err = c.preview(records); err != nil {
+		return err
+	}
+
+	displayPath, _ := utils.DisplayShortpath(absolute(sourceDir))
+
+	if !ctx.Bool("rewrite") {
+		fmt.Fprintf(c.out, "\npreviewed %d ADRs from %s. use --rewrite to convert them\n", len(records), displayPath)
+
+		return nil
+	}
+
+	outDir := ctx.String("out")
+	if outDir == "" {
+		err = rewriteInPlace(records)
+	} else {
+		err = writeTo(records, outDir)
+		displayPath, _ = utils.DisplayShortpath(absolute(outDir))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(theme.ApplicationTheme().TitleStyle().Render(
+		fmt.Sprintf("\nconverted %d ADRs\nin %s", len(records), displayPath),
+	))
+
+	return nil
+}
+
+// loadADRTools parses every ADR-named markdown file in dir as an adr-tools document, ordered by sequence.
+func loadADRTools(dir string) ([]*imported, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %w", err)
+	}
+
+	var records []*imported
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != "."+render.DocumentFormatMarkdown.Extension() {
+			continue
+		}
+
+		if _, seqErr := utils.SequenceFromFilename(entry.Name()); seqErr != nil {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, fmt.Errorf("error reading ADR: %w", readErr)
+		}
+
+		record, warnings, parseErr := adr.ParseADRTools(path, content)
+		if parseErr != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", entry.Name(), parseErr)
+		}
+
+		records = append(records, &imported{
+			file:     adr.File{ADR: record, Path: path, Warnings: warnings},
+			filename: utils.Slugify(record.SequencedTitle()) + "." + render.DocumentFormatMarkdown.Extension(),
+		})
+	}
+
+	slices.SortStableFunc(records, func(a, b *imported) int { return a.file.Sequence - b.file.Sequence })
+
+	return records, nil
+}
+
+// relink points links between imported ADRs at their adr-er titles and filenames, and superseded statuses at their
+// replacements, as lifecycle names them.
+func relink(records []*imported, lifecycle *adr.Lifecycle) {
+	bySequence := make(map[int]*imported, len(records))
+	for _, record := range records {
+		bySequence[record.file.Sequence] = record
+	}
+
+	for _, record := range records {
+		for i, link := range record.file.Links {
+			target, ok := bySequence[link.Target]
+			if !ok {
+				continue
+			}
+
+			record.file.Links[i].TargetTitle = target.file.SequencedTitle()
+			record.file.Links[i].TargetPath = target.filename
+		}
+
+		record.file.RelinkSuperseded(lifecycle)
+	}
+}
+
+// preview writes a table of the imported ADRs.
+func (c *Command) preview(records []*imported) error {
+	//nolint:mnd // layout is all magic
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "SEQ\tTITLE\tSTATUS\tDATE\tLINKS\tWARNINGS")
+
+	for _, record := range records {
+		date := ""
+		if !record.file.Created.IsZero() {
+			date = record.file.Created.Format(time.DateOnly)
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%d\n",
+			utils.PadValue(record.file.Sequence, globals.NumericPadWidth), record.file.Title,
+			c.lifecycle.Label(record.file.Status), date, len(record.file.Links), len(record.file.Warnings),
+		)
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("error writing preview: %w", err)
+	}
+
+	return nil
+}
+
+// render renders an imported ADR through the default markdown template.
+func (r *imported) render() ([]byte, error) {
+	tpl, err := render.DefaultTemplateForFormat(render.DocumentFormatMarkdown)
+	if err != nil {
+		return nil, fmt.Errorf("error loading template: %w", err)
+	}
+
+	document, err := r.file.BuildDocument(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s: %w", r.filename, err)
+	}
+
+	return document.Content, nil
+}
+
+// rewriteInPlace replaces each adr-tools ADR with its adr-er rendering, renaming files whose names change.
+// every ADR is rendered before anything is written. renamed ADRs are written to their new names first, then the rest
+// are replaced together with utils.ReplaceFiles: if either step fails, the new files are removed and the originals are
+// left as they were. the originals of renamed ADRs are only removed once everything else is written.
+func rewriteInPlace(records []*imported) error {
+	replaced := make(map[string][]byte, len(records))
+	renamed := make(map[string][]byte, len(records))
+	originals := make([]string, 0, len(records))
+
+	for _, record := range records {
+		content, err := record.render()
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(filepath.Dir(record.file.Path), record.filename)
+		if target == record.file.Path {
+			replaced[target] = content
+
+			continue
+		}
+
+		renamed[target] = content
+		originals = append(originals, record.file.Path)
+	}
+
+	// renamed: write the new files first, so a failure never loses an original
+	written := make([]string, 0, len(renamed))
+	rollback := func(err error) error {
+		for _, path := range written {
+			if removeErr := os.Remove(path); removeErr != nil {
+				err = errors.Join(err, fmt.Errorf("error rolling back %s: %w", filepath.Base(path), removeErr))
+			}
+		}
+
+		return err
+	}
+
+	for target, content := range renamed {
+		if err := writeNew(target, content); err != nil {
+			return rollback(err)
+		}
+
+		written = append(written, target)
+	}
+
+	if err := utils.ReplaceFiles(replaced); err != nil {
+		return rollback(fmt.Errorf("error rewriting ADRs, no changes were made: %w", err))
+	}
+
+	// everything is written. leftover originals are reported, but the import stands
+	var err error
+
+	for _, original := range originals {
+		if removeErr := os.Remove(original); removeErr != nil {
+			err = errors.Join(err, fmt.Errorf("error removing %s: %w", filepath.Base(original), removeErr))
+		}
+	}
+
+	return err
+}
+
+// writeTo writes each ADR's adr-er rendering to outDir. existing files are never overwritten.
+func writeTo(records []*imported, outDir string) error {
+	//nolint:mnd // not magic, these are the standard permissions
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	for _, record := range records {
+		content, err := record.render()
+		if err != nil {
+			return err
+		}
+
+		if err = writeNew(filepath.Join(outDir, record.filename), content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeNew writes content to a file that mustn't already exist.
+func writeNew(path string, content []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:mnd,gosec // not magic
+	if err != nil {
+		return fmt.Errorf("could not create file %s: %w", filepath.Base(path), err)
+	}
+
+	_, err = file.Write(content)
+
+	return errors.Join(err, file.Close())
+}
+
+// absolute returns the absolute form of path, or path itself if it can't be made absolute.
+func absolute(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return path
+}
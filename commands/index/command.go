@@ -0,0 +1,94 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for generating the ADR index.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle normalizes statuses for display
+	lifecycle *adr.Lifecycle
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "file",
+			Usage: "index file to write. relative paths are within the ADR directory",
+			Value: globals.IndexFileName,
+		},
+	}
+}
+
+// Action writes the index of every ADR to the index file.
+// only the generated block is replaced, so anything written by hand around it is kept.
+func (c *Command) Action(ctx *cli.Context) error {
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	indexPath := ctx.String("file")
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(c.adrDir, indexPath)
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	// a missing index is fine, it's created
+	existing, err := os.ReadFile(indexPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error reading index: %w", err)
+	}
+
+	exists := err == nil
+
+	updated, err := adr.UpdateIndex(existing, adr.IndexMarkdown(files, c.lifecycle, filepath.Dir(indexPath)))
+	if err != nil {
+		return fmt.Errorf("error updating index: %w", err)
+	}
+
+	if exists {
+		err = utils.ReplaceFile(indexPath, updated)
+	} else {
+		err = os.WriteFile(indexPath, updated, 0o644) //nolint:mnd,gosec // not magic. the index is meant to be read
+	}
+
+	if err != nil {
+		return fmt.Errorf("error writing index: %w", err)
+	}
+
+	displayPath, _ := utils.DisplayShortpath(indexPath)
+	fmt.Println(theme.ApplicationTheme().TitleStyle().Render(
+		fmt.Sprintf("indexed %d ADRs\nin %s", len(files), displayPath),
+	))
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package link
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for linking two existing ADR documents.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string) *Command {
+	return &Command{adrDir: adrDir}
+}
+
+// linkedDocument is an ADR loaded for linking, along with what's needed to rewrite it.
+type linkedDocument struct {
+	path    string
+	content []byte
+	record  *adr.ADR
+}
+
+// Action links two ADRs, given as `<source> <type> <target>`.
+// Both documents are rewritten: the source gets the forward link, the target gets the reverse link.
+// Both writes succeed or neither does.
+func (l *Command) Action(ctx *cli.Context) error {
+	//nolint:mnd // not magic: source, type, target
+	if ctx.Args().Len() != 3 {
+		return globals.ValidationError("arguments", "expected <source> <type> <target>")
+	}
+
+	if l.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	linkType, isReverse, err := adr.ParseLinkType(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("error reading link type: %w", err)
+	}
+
+	// supersession changes status, too. it has its own workflow.
+	if linkType.ForwardName() == adr.LinkTypeSupersedes {
+		return globals.ValidationError("link type", "use the supersede command to supersede an ADR")
+	}
+
+	source, err := l.load(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	target, err := l.load(ctx.Args().Get(2))
+	if err != nil {
+		return err
+	}
+
+	// "4 amended-by 12" is "12 amends 4"
+	if isReverse {
+		source, target = target, source
+	}
+
+	if source.record.Sequence == target.record.Sequence {
+		return globals.ValidationError("target", "an ADR can't link to itself")
+	}
+
+	if source.record.HasLink(linkType.ForwardName(), target.record.Sequence) {
+		return globals.ValidationError("link", "these ADRs are already linked this way")
+	}
+
+	// add both directions, then rewrite both documents
+	forward, reverse := adr.NewLinkPair(linkType, source.record, source.path, target.record, target.path)
+	source.record.Links = append(adr.ResolveLinks(l.adrDir, source.record.Links), forward)
+	target.record.Links = append(adr.ResolveLinks(l.adrDir, target.record.Links), reverse)
+
+	updates := make(map[string][]byte, 2) //nolint:mnd // not magic: source and target
+
+	for _, doc := range []linkedDocument{source, target} {
+		rewritten, rewriteErr := adr.RewriteLinks(doc.path, doc.content, doc.record)
+		if rewriteErr != nil {
+			return fmt.Errorf("error rewriting %s: %w", doc.record.SequencedTitle(), rewriteErr)
+		}
+
+		updates[doc.path] = rewritten
+	}
+
+	if err = utils.ReplaceFiles(updates); err != nil {
+		return fmt.Errorf("error writing links, no changes were made: %w", err)
+	}
+
+	fmt.Println(theme.ApplicationTheme().TitleStyle().Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		fmt.Sprintf("%s: %s", source.record.SequencedTitle(), forward.Markdown()),
+		fmt.Sprintf("%s: %s", target.record.SequencedTitle(), reverse.Markdown()),
+	)))
+
+	return nil
+}
+
+// load locates and parses the ADR with the sequence number given in arg.
+func (l *Command) load(arg string) (linkedDocument, error) {
+	sequence, err := strconv.Atoi(arg)
+	if err != nil {
+		return linkedDocument{}, globals.ValidationError("sequence", fmt.Sprintf("%q is not a sequence number", arg))
+	}
+
+	path, err := utils.FindBySequence(l.adrDir, sequence)
+	if err != nil {
+		return linkedDocument{}, fmt.Errorf("error locating ADR: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return linkedDocument{}, fmt.Errorf("error reading ADR: %w", err)
+	}
+
+	record, _, err := adr.Parse(path, content)
+	if err != nil {
+		return linkedDocument{}, fmt.Errorf("error parsing ADR: %w", err)
+	}
+
+	return linkedDocument{path: path, content: content, record: record}, nil
+}
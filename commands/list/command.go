@@ -0,0 +1,288 @@
+package list
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// sort keys.
+const (
+	sortSequence = "sequence"
+	sortTitle    = "title"
+	sortStatus   = "status"
+	sortDate     = "date"
+)
+
+// Command wraps the cli command for listing existing ADR documents.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle normalizes statuses for display and filtering
+	lifecycle *adr.Lifecycle
+	// out is where the listing is written
+	out io.Writer
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+		out:       os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "output format: table, json, or csv",
+			Value:   formatTable,
+		},
+		&cli.StringSliceFlag{
+			Name:  "status",
+			Usage: "only list ADRs with this status. may be repeated",
+		},
+		&cli.BoolFlag{
+			Name:  "active",
+			Usage: "only list ADRs whose status is active, eg: accepted",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "only list ADRs with this tag. may be repeated",
+		},
+		&cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort by sequence, title, status, or date",
+			Value: sortSequence,
+		},
+		&cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the sort order",
+		},
+	}
+}
+
+// row is a single listed ADR. it's shared by every output format.
+type row struct {
+	Sequence int      `json:"sequence"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Date     string   `json:"date"`
+	Tags     []string `json:"tags"`
+	Path     string   `json:"path"`
+}
+
+// Action lists the ADRs in the directory, filtered and sorted according to flags.
+func (c *Command) Action(ctx *cli.Context) error {
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	rows := c.filter(files, ctx.StringSlice("status"), ctx.StringSlice("tag"), ctx.Bool("active"))
+
+	if err = sortRows(rows, ctx.String("sort"), ctx.Bool("reverse")); err != nil {
+		return err
+	}
+
+	switch format := ctx.String("format"); format {
+	case formatTable:
+		// only color for humans
+		colorize := func(status string) string { return status }
+		if f, ok := c.out.(interface{ Fd() uintptr }); ok && term.IsTerminal(int(f.Fd())) {
+			colorize = func(status string) string { return theme.StatusStyle(c.lifecycle.Color(status)).Render(status) }
+		}
+
+		return writeTable(c.out, rows, colorize)
+	case formatJSON:
+		return writeJSON(c.out, rows)
+	case formatCSV:
+		return writeCSV(c.out, rows)
+	default:
+		return globals.ValidationError("format", fmt.Sprintf("unsupported format %q", format))
+	}
+}
+
+// filter builds rows for the files matching every given status and tag filter. empty filters match everything.
+// with activeOnly, only files with an active status match.
+func (c *Command) filter(files []adr.File, statuses, tags []string, activeOnly bool) []row {
+	for i, status := range statuses {
+		statuses[i] = c.lifecycle.Label(status)
+	}
+
+	rows := make([]row, 0, len(files))
+
+	for _, file := range files {
+		status := c.lifecycle.Label(file.Status)
+
+		if len(statuses) > 0 && !slices.ContainsFunc(statuses, func(s string) bool { return strings.EqualFold(s, status) }) {
+			continue
+		}
+
+		if activeOnly && !c.lifecycle.IsActive(status) {
+			continue
+		}
+
+		if len(tags) > 0 && !slices.ContainsFunc(tags, func(t string) bool { return slices.Contains(file.Tags, t) }) {
+			continue
+		}
+
+		displayPath, _ := utils.DisplayShortpath(file.Path)
+
+		date := ""
+		if !file.Created.IsZero() {
+			date = file.Created.Format(time.DateOnly)
+		}
+
+		// always a list, never null, for json consumers
+		fileTags := file.Tags
+		if fileTags == nil {
+			fileTags = []string{}
+		}
+
+		rows = append(rows, row{
+			Sequence: file.Sequence,
+			Title:    file.Title,
+			Status:   status,
+			Date:     date,
+			Tags:     fileTags,
+			Path:     displayPath,
+		})
+	}
+
+	return rows
+}
+
+// sortRows sorts rows in place by the given key. ties are broken by sequence number.
+func sortRows(rows []row, key string, reverse bool) error {
+	var compare func(a, b row) int
+
+	switch key {
+	case sortSequence:
+		compare = func(a, b row) int { return cmp.Compare(a.Sequence, b.Sequence) }
+	case sortTitle:
+		compare = func(a, b row) int { return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) }
+	case sortStatus:
+		compare = func(a, b row) int { return cmp.Compare(a.Status, b.Status) }
+	case sortDate:
+		compare = func(a, b row) int { return cmp.Compare(a.Date, b.Date) }
+	default:
+		return globals.ValidationError("sort", fmt.Sprintf("unsupported sort %q", key))
+	}
+
+	slices.SortStableFunc(rows, func(a, b row) int {
+		result := cmp.Or(compare(a, b), cmp.Compare(a.Sequence, b.Sequence))
+		if reverse {
+			return -result
+		}
+
+		return result
+	})
+
+	return nil
+}
+
+// writeTable writes rows as aligned columns. statuses are rendered through colorize.
+func writeTable(out io.Writer, rows []row, colorize func(status string) string) error {
+	// align plain text first: escape codes would throw off the column widths
+	var aligned bytes.Buffer
+
+	//nolint:mnd // layout is all magic
+	table := tabwriter.NewWriter(&aligned, 0, 0, 2, ' ', 0)
+
+	const header = "SEQ\tTITLE\tSTATUS\tDATE\tPATH"
+
+	fmt.Fprintln(table, header)
+
+	for _, r := range rows {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+			utils.PadValue(r.Sequence, globals.NumericPadWidth), r.Title, r.Status, r.Date, r.Path,
+		)
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("error writing table: %w", err)
+	}
+
+	// every status starts in the header's STATUS column. tabwriter aligns by rune, so offsets are in runes.
+	lines := strings.SplitAfter(aligned.String(), "\n")
+	column := utf8.RuneCountInString(lines[0][:strings.Index(lines[0], "STATUS")])
+
+	for i, r := range rows {
+		line := []rune(lines[i+1])
+		end := column + utf8.RuneCountInString(r.Status)
+		lines[i+1] = string(line[:column]) + colorize(r.Status) + string(line[end:])
+	}
+
+	if _, err := io.WriteString(out, strings.Join(lines, "")); err != nil {
+		return fmt.Errorf("error writing table: %w", err)
+	}
+
+	return nil
+}
+
+// writeJSON writes rows as an indented json array.
+func writeJSON(out io.Writer, rows []row) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(rows); err != nil {
+		return fmt.Errorf("error writing json: %w", err)
+	}
+
+	return nil
+}
+
+// writeCSV writes rows as csv with a header line. tags are joined with semicolons.
+func writeCSV(out io.Writer, rows []row) error {
+	writer := csv.NewWriter(out)
+
+	records := [][]string{{"sequence", "title", "status", "date", "tags", "path"}}
+	for _, r := range rows {
+		records = append(records, []string{
+			strconv.Itoa(r.Sequence), r.Title, r.Status, r.Date, strings.Join(r.Tags, ";"), r.Path,
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("error writing csv: %w", err)
+	}
+
+	return nil
+}
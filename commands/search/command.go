@@ -0,0 +1,155 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for searching ADR documents.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// out is where results are written
+	out *os.File
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string) *Command {
+	return &Command{
+		adrDir: adrDir,
+		out:    os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "show at most this many ADRs. 0 shows all",
+			Value: 0,
+		},
+		&cli.IntFlag{
+			Name:  "lines",
+			Usage: "show at most this many matching lines per ADR. 0 shows all",
+			Value: 3, //nolint:mnd // not magic
+		},
+	}
+}
+
+// styles holds how results are drawn. zero-value styles render plain text.
+type styles struct {
+	title lipgloss.Style
+	muted lipgloss.Style
+	match lipgloss.Style
+}
+
+// Action searches titles and bodies of every ADR for the query in the arguments, printing ranked results
+// with their matching lines. quoted phrases are matched as a whole.
+func (c *Command) Action(ctx *cli.Context) error {
+	terms := adr.SearchArgTerms(ctx.Args().Slice())
+	if len(terms) == 0 {
+		return globals.ValidationError("arguments", "expected <query>")
+	}
+
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	results, err := adr.Search(files, terms)
+	if err != nil {
+		return fmt.Errorf("error searching ADRs: %w", err)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(c.out, "no ADRs match %q\n", strings.Join(ctx.Args().Slice(), " "))
+
+		return nil
+	}
+
+	if limit := ctx.Int("limit"); limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	// only style for humans
+	resultStyles := styles{title: lipgloss.NewStyle(), muted: lipgloss.NewStyle(), match: lipgloss.NewStyle()}
+	if term.IsTerminal(int(c.out.Fd())) {
+		appTheme := theme.ApplicationTheme()
+		resultStyles = styles{
+			title: lipgloss.NewStyle().Bold(true).Foreground(appTheme.PrimaryColor),
+			muted: lipgloss.NewStyle().Foreground(appTheme.KeyColors[theme.ThemeColorNormalFG]).Faint(true),
+			match: lipgloss.NewStyle().Bold(true).Foreground(appTheme.AccentColor),
+		}
+	}
+
+	return writeResults(c.out, results, ctx.Int("lines"), resultStyles)
+}
+
+// writeResults writes each result's title and path, followed by up to maxLines numbered matching lines.
+func writeResults(out io.Writer, results []adr.SearchResult, maxLines int, resultStyles styles) error {
+	var builder strings.Builder
+
+	for i, result := range results {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+
+		displayPath, _ := utils.DisplayShortpath(result.Path)
+		builder.WriteString(resultStyles.title.Render(result.SequencedTitle()))
+		builder.WriteString(" " + resultStyles.muted.Render(displayPath) + "\n")
+
+		hits := result.Hits
+		if maxLines > 0 && len(hits) > maxLines {
+			hits = hits[:maxLines]
+		}
+
+		for _, hit := range hits {
+			builder.WriteString(resultStyles.muted.Render(fmt.Sprintf("%5d:", hit.Line)))
+			builder.WriteString(" " + highlight(hit, resultStyles.match) + "\n")
+		}
+
+		if hidden := len(result.Hits) - len(hits); hidden > 0 {
+			builder.WriteString(resultStyles.muted.Render(fmt.Sprintf("       … %d more", hidden)) + "\n")
+		}
+	}
+
+	if _, err := io.WriteString(out, builder.String()); err != nil {
+		return fmt.Errorf("error writing results: %w", err)
+	}
+
+	return nil
+}
+
+// highlight renders the hit's line with each match drawn in style.
+func highlight(hit adr.SearchHit, style lipgloss.Style) string {
+	var builder strings.Builder
+
+	last := 0
+	for _, match := range hit.Matches {
+		builder.WriteString(hit.Text[last:match[0]])
+		builder.WriteString(style.Render(hit.Text[match[0]:match[1]]))
+		last = match[1]
+	}
+
+	builder.WriteString(hit.Text[last:])
+
+	return strings.TrimSpace(builder.String())
+}
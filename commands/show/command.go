@@ -0,0 +1,99 @@
+package show
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mistakenelf/teacup/markdown"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for printing a single ADR document.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// out is where the document is written
+	out *os.File
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string) *Command {
+	return &Command{
+		adrDir: adrDir,
+		out:    os.Stdout,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "raw",
+			Usage: "print the file as-is, without styling",
+		},
+	}
+}
+
+// Action prints the ADR identified by the first argument, either a sequence number or a slug.
+// Markdown is styled the same way as the view tui and wrapped to the terminal width. When stdout isn't a terminal,
+// or --raw is given, the file is printed as-is.
+func (s *Command) Action(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return globals.ValidationError("arguments", "expected <sequence or slug>")
+	}
+
+	if s.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	path, err := adr.FindFile(s.adrDir, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading ADR: %w", err)
+	}
+
+	format, _ := render.FormatForExtension(filepath.Ext(path))
+	isTerminal := term.IsTerminal(int(s.out.Fd()))
+
+	// styling is only for humans, and only for markdown
+	if ctx.Bool("raw") || !isTerminal || format != render.DocumentFormatMarkdown {
+		return writeRaw(s.out, content)
+	}
+
+	width, _ := commands.ScreenDimensions()
+
+	styled, err := markdown.RenderMarkdown(width, string(adr.StripFrontMatter(content)))
+	if err != nil {
+		return fmt.Errorf("error rendering ADR: %w", err)
+	}
+
+	return writeRaw(s.out, []byte(styled))
+}
+
+// writeRaw writes content to out, ensuring it ends with a newline.
+func writeRaw(out io.Writer, content []byte) error {
+	if _, err := out.Write(content); err != nil {
+		return fmt.Errorf("error writing ADR: %w", err)
+	}
+
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return fmt.Errorf("error writing ADR: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package site
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/site"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for generating a static html site from the ADR log.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle normalizes statuses for display and filtering
+	lifecycle *adr.Lifecycle
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "out",
+			Aliases: []string{"o"},
+			Usage:   "directory to write the site to",
+			Value:   "public",
+		},
+		&cli.StringFlag{
+			Name:  "title",
+			Usage: "site title",
+			Value: "Architecture Decision Records",
+		},
+	}
+}
+
+// Action renders every ADR to a static html site.
+func (c *Command) Action(ctx *cli.Context) error {
+	if c.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	files, err := adr.LoadDirectory(c.adrDir)
+	if err != nil {
+		return fmt.Errorf("error loading ADRs: %w", err)
+	}
+
+	outDir := ctx.String("out")
+
+	if err = site.NewSite(ctx.String("title"), c.lifecycle).Build(files, outDir); err != nil {
+		return fmt.Errorf("error building site: %w", err)
+	}
+
+	displayPath := outDir
+	if absolute, absErr := filepath.Abs(outDir); absErr == nil {
+		displayPath, _ = utils.DisplayShortpath(absolute)
+	}
+
+	fmt.Println(theme.ApplicationTheme().TitleStyle().Render(
+		fmt.Sprintf("built site for %d ADRs\nin %s", len(files), displayPath),
+	))
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package status
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for changing the status of an existing ADR document.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// lifecycle governs which status changes are legal
+	lifecycle *adr.Lifecycle
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+	}
+}
+
+// Action changes the status of the ADR identified by the first argument to the status given by the rest.
+// The document is rewritten in place: only its status is changed, and the rest of the content is left intact.
+func (s *Command) Action(ctx *cli.Context) error {
+	//nolint:mnd // not magic: a sequence and a status
+	if ctx.Args().Len() < 2 {
+		return globals.ValidationError("arguments", "expected <sequence> <status>")
+	}
+
+	sequence, err := strconv.Atoi(ctx.Args().First())
+	if err != nil {
+		return globals.ValidationError("sequence", fmt.Sprintf("%q is not a sequence number", ctx.Args().First()))
+	}
+
+	newStatus := strings.Join(ctx.Args().Tail(), " ")
+
+	if s.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	// find and parse the document
+	path, err := utils.FindBySequence(s.adrDir, sequence)
+	if err != nil {
+		return fmt.Errorf("error locating ADR: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading ADR: %w", err)
+	}
+
+	record, _, err := adr.Parse(path, content)
+	if err != nil {
+		return fmt.Errorf("error parsing ADR: %w", err)
+	}
+
+	// apply the change through the lifecycle
+	previous := record.Status
+
+	if err = record.TransitionStatus(s.lifecycle, newStatus, time.Now()); err != nil {
+		return fmt.Errorf("error changing status: %w", err)
+	}
+
+	// rewrite the document in place
+	updated, err := adr.RewriteStatus(path, content, record)
+	if err != nil {
+		return fmt.Errorf("error rewriting ADR: %w", err)
+	}
+
+	if err = utils.ReplaceFile(path, updated); err != nil {
+		return fmt.Errorf("error writing ADR: %w", err)
+	}
+
+	displayPath, _ := utils.DisplayShortpath(path)
+	fmt.Println(theme.ApplicationTheme().TitleStyle().Render(
+		fmt.Sprintf("%s: %s → %s\nin %s", record.SequencedTitle(), s.styleStatus(previous), s.styleStatus(record.Status), displayPath),
+	))
+
+	return nil
+}
+
+// styleStatus renders status in its display color.
+func (s *Command) styleStatus(status string) string {
+	return theme.StatusStyle(s.lifecycle.Color(status)).Render(status)
+}
@@ -0,0 +1,219 @@
+package supersede
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/commands/create"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/theme"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var _ commands.CliCommand = (*Command)(nil)
+
+// Command wraps the cli command for replacing an existing ADR with a new one.
+type Command struct {
+	// directory holding architecture decision records
+	adrDir string
+	// the next integer sequence for the adrs in this directory
+	nextSequence int
+	// lifecycle governs which status changes are legal
+	lifecycle *adr.Lifecycle
+	// config holds the repository's conventions, for the replacement's form and template
+	config *config.Config
+}
+
+// NewCommand is a constructor.
+func NewCommand(adrDir string, nextSequence int, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:       adrDir,
+		nextSequence: nextSequence,
+		lifecycle:    cfg.Lifecycle(),
+		config:       cfg,
+	}
+}
+
+// Flags returns the cli flags this command responds to.
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "template",
+			Aliases: []string{"t"},
+			Usage:   "template to write the replacement with. defaults to the superseded ADR's: madr or default",
+		},
+	}
+}
+
+// Action supersedes the ADR identified by the first argument.
+// The create form opens pre-filled from the superseded ADR. On confirmation, the new ADR is written with a
+// "supersedes" link, and the old ADR's status and links are rewritten to point to its replacement.
+// Both writes succeed or neither.
+//
+//nolint:funlen // it's a two-document transaction, it's going to be long
+func (s *Command) Action(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return globals.ValidationError("arguments", "expected <sequence>")
+	}
+
+	sequence, err := strconv.Atoi(ctx.Args().First())
+	if err != nil {
+		return globals.ValidationError("sequence", fmt.Sprintf("%q is not a sequence number", ctx.Args().First()))
+	}
+
+	if s.adrDir == "" {
+		return globals.ValidationError("directory", "no ADR directory found. use --dir to set one")
+	}
+
+	// load the ADR being superseded
+	oldPath, err := utils.FindBySequence(s.adrDir, sequence)
+	if err != nil {
+		return fmt.Errorf("error locating ADR: %w", err)
+	}
+
+	oldContent, err := os.ReadFile(oldPath)
+	if err != nil {
+		return fmt.Errorf("error reading ADR: %w", err)
+	}
+
+	superseded, _, err := adr.Parse(oldPath, oldContent)
+	if err != nil {
+		return fmt.Errorf("error parsing ADR: %w", err)
+	}
+
+	// fail fast, before anyone fills in a form for nothing
+	supersededStatus, ok := s.lifecycle.Superseded()
+	if !ok {
+		return globals.ValidationError("superseded-status", "no status for superseded ADRs. set one in the config")
+	}
+
+	if err = s.lifecycle.CheckTransition(superseded.Status, supersededStatus); err != nil {
+		return fmt.Errorf("can't supersede %s: %w", superseded.SequencedTitle(), err)
+	}
+
+	// the replacement, pre-filled from the superseded ADR
+	replacement := &adr.ADR{
+		Sequence:      s.nextSequence,
+		Title:         superseded.Title,
+		Context:       superseded.Context,
+		Decision:      "",
+		Status:        "",
+		Consequences:  "",
+		Created:       time.Now(),
+		StatusChanged: time.Time{},
+		Authors:       nil,
+		Deciders:      superseded.Deciders,
+		Tags:          superseded.Tags,
+		Links:         []adr.Link{adr.NewLink(adr.LinkTypeSupersedes, superseded, filepath.Base(oldPath))},
+		StatusHistory: nil,
+
+		ConsideredOptions: nil,
+		ProsAndCons:       "",
+		Consulted:         superseded.Consulted,
+		Informed:          superseded.Informed,
+	}
+
+	// the replacement is written in the same format as the ADR it replaces
+	format, _ := render.FormatForExtension(filepath.Ext(oldPath))
+
+	// with the superseded ADR's template, unless another is asked for
+	templateID := ctx.String("template")
+	if templateID == "" {
+		templateID = adr.DetectTemplate(oldPath, oldContent)
+	}
+
+	tpl, err := render.TemplateForFormat(templateID, format, render.LocalTemplateDir(s.adrDir))
+	if errors.As(err, &render.TemplateNotFoundError{}) && !ctx.IsSet("template") {
+		tpl, err = render.DefaultTemplateForFormat(format, render.LocalTemplateDir(s.adrDir))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error finding template: %w", err)
+	}
+
+	confirmText := fmt.Sprintf(
+		"this will create next sequence number %d \nand mark %s as %s",
+		s.nextSequence,
+		superseded.SequencedTitle(),
+		supersededStatus,
+	)
+
+	confirmed, err := create.RunForm(replacement, s.config, create.FieldsForTemplate(tpl), confirmText)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		theme.ApplicationTheme().RenderCancelMessage()
+
+		return nil
+	}
+
+	// prepare both documents before touching the filesystem
+	document, err := replacement.BuildDocument(tpl)
+	if err != nil {
+		return fmt.Errorf("error rendering document: %w", err)
+	}
+
+	if err = superseded.Supersede(s.lifecycle, replacement, document.Filename(), time.Now()); err != nil {
+		return fmt.Errorf("error changing status: %w", err)
+	}
+
+	// the superseded ADR also links back to its replacement
+	supersedes, _, err := adr.ParseLinkType(adr.LinkTypeSupersedes)
+	if err != nil {
+		return fmt.Errorf("error reading link type: %w", err)
+	}
+
+	superseded.Links = append(
+		adr.ResolveLinks(s.adrDir, superseded.Links),
+		adr.NewLink(supersedes.ReverseName(), replacement, document.Filename()),
+	)
+
+	rewritten, err := adr.RewriteStatus(oldPath, oldContent, superseded)
+	if err != nil {
+		return fmt.Errorf("error rewriting ADR: %w", err)
+	}
+
+	if rewritten, err = adr.RewriteLinks(oldPath, rewritten, superseded); err != nil {
+		return fmt.Errorf("error rewriting ADR: %w", err)
+	}
+
+	// commit both, rolling back the new document if the old one can't be updated
+	newPath := filepath.Join(s.adrDir, document.Filename())
+
+	if err = document.Write(s.adrDir); err != nil {
+		return fmt.Errorf("error writing adr document: %w", err)
+	}
+
+	if err = utils.ReplaceFile(oldPath, rewritten); err != nil {
+		if rollbackErr := os.Remove(newPath); rollbackErr != nil {
+			return errors.Join(
+				fmt.Errorf("error updating superseded ADR: %w", err),
+				fmt.Errorf("error rolling back %s: %w", newPath, rollbackErr),
+			)
+		}
+
+		return fmt.Errorf("error updating superseded ADR, no changes were made: %w", err)
+	}
+
+	newDisplayPath, _ := utils.DisplayShortpath(newPath)
+	oldDisplayPath, _ := utils.DisplayShortpath(oldPath)
+	fmt.Print(theme.ApplicationTheme().TitleStyle().Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		"wrote ADR to "+newDisplayPath,
+		"marked "+oldDisplayPath+" as superseded",
+	)))
+
+	return nil
+}
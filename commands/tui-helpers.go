@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/therealkevinard/adr-er/globals"
@@ -24,6 +25,17 @@ func StrLenValidator(fieldLabel string, min, max int) func(string) error {
 	}
 }
 
+// RequiredValidator returns a func that ensures a string isn't blank.
+func RequiredValidator(fieldLabel string) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return globals.ValidationError(fieldLabel, "is required")
+		}
+
+		return nil
+	}
+}
+
 // ScreenDimensions returns the terminal width and height, constrained to `80 <= x <= 120`
 //
 //nolint:mnd // ui layout is all magic
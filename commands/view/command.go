@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/therealkevinard/adr-er/adr"
 	"github.com/therealkevinard/adr-er/commands"
+	"github.com/therealkevinard/adr-er/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -14,11 +16,16 @@ var _ commands.CliCommand = (*Command)(nil)
 type Command struct {
 	// directory holding architecture decision records
 	adrDir string
+	// lifecycle colors the listed statuses
+	lifecycle *adr.Lifecycle
 }
 
 // NewCommand is a constructor.
-func NewCommand(adrDir string) *Command {
-	return &Command{adrDir: adrDir}
+func NewCommand(adrDir string, cfg *config.Config) *Command {
+	return &Command{
+		adrDir:    adrDir,
+		lifecycle: cfg.Lifecycle(),
+	}
 }
 
 // Action runs the TUI application for viewing Architectural Decision Records.
@@ -28,7 +35,7 @@ func (v *Command) Action(_ *cli.Context) error {
 	}
 
 	// initialize the app models
-	model, err := newRootModel(v.adrDir)
+	model, err := newRootModel(v.adrDir, v.lifecycle)
 	if err != nil {
 		return fmt.Errorf("error initializing tui: %w", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/adr"
 	"github.com/therealkevinard/adr-er/commands"
 	tui_commands "github.com/therealkevinard/adr-er/commands/view/tui-commands"
 	"github.com/therealkevinard/adr-er/globals"
@@ -25,10 +26,10 @@ type FileListModel struct {
 	keymap fileListKeyMap
 }
 
-// New creates a new FileListModel, bound ot the provided workDirectory.
-func New(workDirectory string) (FileListModel, error) {
+// New creates a new FileListModel, bound ot the provided workDirectory. statuses are displayed using lifecycle's colors.
+func New(workDirectory string, lifecycle *adr.Lifecycle) (FileListModel, error) {
 	// load ADR files from the working directory
-	filesListItems, err := getFilesList(workDirectory)
+	filesListItems, err := getFilesList(workDirectory, lifecycle)
 	if err != nil {
 		return FileListModel{}, fmt.Errorf("error listing files: %w", err)
 	}
@@ -136,9 +137,9 @@ func (m FileListModel) SetIsActive(active bool) FileListModel {
 }
 
 // getFilesList reads a directory of files, returning []list.Item.
-// the returned sliced is suitable for pupulating the fileList model
+// the returned sliced is suitable for pupulating the fileList model. files that parse as ADRs carry their status.
 // TODO: this should leverage the regex file filter used elsewhere to only show ADR files (per naming convention)
-func getFilesList(workDirectory string) ([]list.Item, error) {
+func getFilesList(workDirectory string, lifecycle *adr.Lifecycle) ([]list.Item, error) {
 	items, err := os.ReadDir(workDirectory)
 	if err != nil {
 		return nil, fmt.Errorf("error reading dir %s: %w", workDirectory, err)
@@ -158,11 +159,19 @@ func getFilesList(workDirectory string) ([]list.Item, error) {
 			continue
 		}
 
-		filesList = append(filesList, NewItem(
+		listItem := NewItem(
 			info.Name(),
 			workDirectory,
 			info.ModTime(),
-		))
+		)
+
+		// unparseable files are still listed, just without a status
+		if record, _, loadErr := adr.Load(listItem.FullPath()); loadErr == nil && record.Status != "" {
+			status := lifecycle.Label(record.Status)
+			listItem = listItem.WithStatus(status, theme.StatusStyle(lifecycle.Color(status)))
+		}
+
+		filesList = append(filesList, listItem)
 	}
 
 	return filesList, nil
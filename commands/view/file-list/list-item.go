@@ -4,6 +4,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/dustin/go-humanize"
 )
 
@@ -12,28 +13,50 @@ type Item struct {
 	name     string
 	parent   string
 	modified time.Time
+	// status is the ADR's status, empty for files that aren't ADRs
+	status      string
+	statusStyle lipgloss.Style
 }
 
 // NewItem builds a new item from input.
 func NewItem(name, parent string, modtime time.Time) Item {
 	return Item{
-		name:     name,
-		parent:   parent,
-		modified: modtime,
+		name:        name,
+		parent:      parent,
+		modified:    modtime,
+		status:      "",
+		statusStyle: lipgloss.NewStyle(),
 	}
 }
 
+// WithStatus returns a copy of the item that displays status, rendered with style.
+func (i Item) WithStatus(status string, style lipgloss.Style) Item {
+	i.status = status
+	i.statusStyle = style
+
+	return i
+}
+
 // Title is used by list.DefaultDelegate.
 func (i Item) Title() string { return i.name }
 
 // Description is used by list.DefaultDelegate.
 func (i Item) Description() string {
-	return humanize.RelTime(i.modified, time.Now(), "ago", "from now")
+	modified := humanize.RelTime(i.modified, time.Now(), "ago", "from now")
+	if i.status == "" {
+		return modified
+	}
+
+	return i.statusStyle.Render(i.status) + " · " + modified
 }
 
-// FilterValue returns the value to reference when the list is in filter mode.
+// FilterValue returns the value to reference when the list is in filter mode. ADRs can be filtered by status, too.
 func (i Item) FilterValue() string {
-	return i.name
+	if i.status == "" {
+		return i.name
+	}
+
+	return i.name + " " + i.status
 }
 
 // FullPath returns the absolute path to the file item.
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/adr"
 	"github.com/therealkevinard/adr-er/commands"
 	file_list "github.com/therealkevinard/adr-er/commands/view/file-list"
 	file_viewer "github.com/therealkevinard/adr-er/commands/view/file-viewer"
@@ -33,7 +34,7 @@ type rootModel struct {
 	screenH int
 }
 
-func newRootModel(workDirectory string) (*rootModel, error) {
+func newRootModel(workDirectory string, lifecycle *adr.Lifecycle) (*rootModel, error) {
 	//nolint:varnamelen // i approve these varnames
 	var (
 		err error
@@ -43,7 +44,7 @@ func newRootModel(workDirectory string) (*rootModel, error) {
 	)
 
 	// init the fileList
-	fl, err = file_list.New(workDirectory)
+	fl, err = file_list.New(workDirectory, lifecycle)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing filelist: %w", err)
 	}
@@ -0,0 +1,415 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/theme"
+	"gopkg.in/yaml.v3"
+)
+
+var _ globals.Validator = (*Config)(nil)
+
+// FileName is the name of the repository configuration file.
+const FileName = ".adr-er.yaml"
+
+// limits on the configurable pad width. beyond 10 digits, sequence numbers stop being readable.
+const (
+	minPadWidth = 1
+	maxPadWidth = 10
+)
+
+// form fields that can be made required. the title is always required.
+const (
+	FieldContext           = "context"
+	FieldDecision          = "decision"
+	FieldConsequences      = "consequences"
+	FieldAuthors           = "authors"
+	FieldDeciders          = "deciders"
+	FieldTags              = "tags"
+	FieldConsideredOptions = "considered-options"
+	FieldProsAndCons       = "pros-and-cons"
+	FieldConsulted         = "consulted"
+	FieldInformed          = "informed"
+)
+
+// requirableFields lists every field that can be made required.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var requirableFields = []string{
+	FieldContext, FieldDecision, FieldConsequences, FieldAuthors, FieldDeciders, FieldTags,
+	FieldConsideredOptions, FieldProsAndCons, FieldConsulted, FieldInformed,
+}
+
+// colorPattern matches a display color: a hex color, or an ANSI color number.
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// Status declares a status, and how it's displayed and treated.
+// in the config file, it's either a bare name or a mapping:
+//
+//	statuses:
+//	  - draft
+//	  - name: accepted
+//	    color: "#1a7f37"
+//	    active: true
+type Status struct {
+	// Name is the status, as stored in documents
+	Name string `yaml:"name"`
+	// Color displays the status, as a hex color (eg: "#1a7f37") or an ANSI color number (eg: "212")
+	Color string `yaml:"color,omitempty"`
+	// Terminal statuses are final: ADRs never move out of them
+	Terminal bool `yaml:"terminal,omitempty"`
+	// Active statuses are in effect, eg: accepted
+	Active bool `yaml:"active,omitempty"`
+	// Aliases are other names documents may use for the status, eg: an old spelling
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// UnmarshalYAML reads a status from either a bare name or a mapping.
+func (s *Status) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = Status{Name: node.Value, Color: "", Terminal: false, Active: false, Aliases: nil}
+
+		return nil
+	}
+
+	// a distinct type, so decoding doesn't recurse back into UnmarshalYAML
+	type plain Status
+
+	return node.Decode((*plain)(s))
+}
+
+// Config holds a repository's conventions, read from a .adr-er.yaml file. every field is optional.
+// CLI flags take precedence over the config.
+type Config struct {
+	// path is the file the config was read from. empty when no file was found.
+	path string
+
+	// Dir is the ADR directory. relative paths are relative to the config file.
+	Dir string `yaml:"dir,omitempty"`
+	// Format is the document format new ADRs are written in, eg: "markdown"
+	Format string `yaml:"format,omitempty"`
+	// Template is the template new ADRs are written with, eg: "madr"
+	Template string `yaml:"template,omitempty"`
+	// PadWidth is the number of digits sequence numbers are padded to
+	PadWidth int `yaml:"pad-width,omitempty"`
+
+	// Statuses lists every allowed status, in display order. empty for the default lifecycle.
+	Statuses []Status `yaml:"statuses,omitempty"`
+	// InitialStatuses lists the statuses a new ADR may start with. empty allows all of them.
+	InitialStatuses []string `yaml:"initial-statuses,omitempty"`
+	// Transitions maps a status to the statuses it may move to. empty allows moving between any of them.
+	Transitions map[string][]string `yaml:"transitions,omitempty"`
+	// SupersededStatus is the status `supersede` moves ADRs to, eg: "retired". defaults to "superseded".
+	SupersededStatus string `yaml:"superseded-status,omitempty"`
+
+	// Required lists the form fields that can't be left blank, eg: "consequences"
+	Required []string `yaml:"required,omitempty"`
+	// Editor is the command that opens long text fields and `create --editor` drafts, eg: "code --wait".
+	// defaults to $EDITOR.
+	Editor string `yaml:"editor,omitempty"`
+	// Theme names the tui theme, eg: "dracula"
+	Theme string `yaml:"theme,omitempty"`
+}
+
+// Default returns the configuration used when a repository has none.
+func Default() *Config {
+	return &Config{
+		path:             "",
+		Dir:              "",
+		Format:           string(render.DocumentFormatMarkdown),
+		Template:         render.TemplateIDDefault,
+		PadWidth:         globals.DefaultNumericPadWidth,
+		Statuses:         nil,
+		InitialStatuses:  nil,
+		Transitions:      nil,
+		SupersededStatus: "",
+		Required:         nil,
+		Editor:           "",
+		Theme:            theme.DefaultName,
+	}
+}
+
+// Load finds and reads the config file that applies to dir, searching from dir up to the root of its repository.
+// Without a config file, the defaults are returned. An empty dir is the working directory.
+// Returns an error if the file can't be read or holds invalid settings.
+func Load(dir string) (*Config, error) {
+	path, found, err := Find(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return Default(), nil
+	}
+
+	return Read(path)
+}
+
+// Read reads the config file at path. settings missing from the file keep their defaults.
+func Read(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config %s: %w", path, err)
+	}
+
+	cfg := Default()
+	cfg.path = path
+
+	if err = yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %w", path, err)
+	}
+
+	cfg.normalizeStatuses()
+
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Find searches for a config file from dir up to the root of its repository: the first directory holding .git.
+// outside a repository, the search continues to the filesystem root.
+// Returns the config file's path, and whether one was found.
+func Find(dir string) (string, bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false, fmt.Errorf("error normalizing path %s: %w", dir, err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, FileName)
+		if _, err = os.Stat(candidate); err == nil {
+			return candidate, true, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", false, fmt.Errorf("error reading config %s: %w", candidate, err)
+		}
+
+		// the repository root ends the search
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", false, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false, nil
+		}
+
+		dir = parent
+	}
+}
+
+// Path returns the file the config was read from, or an empty string for the defaults.
+func (c *Config) Path() string { return c.path }
+
+// ADRDir returns the configured ADR directory, resolved against the config file's directory.
+// returns an empty string if no directory is configured.
+func (c *Config) ADRDir() string {
+	if c.Dir == "" || filepath.IsAbs(c.Dir) || c.path == "" {
+		return c.Dir
+	}
+
+	return filepath.Join(filepath.Dir(c.path), c.Dir)
+}
+
+// Lifecycle returns the configured status lifecycle, or the default one if no statuses are configured.
+// without initial statuses, a new ADR may start with any status. without transitions, any non-terminal status may move
+// to any other.
+func (c *Config) Lifecycle() *adr.Lifecycle {
+	if len(c.Statuses) == 0 {
+		return adr.DefaultLifecycle()
+	}
+
+	names := c.StatusNames()
+	definitions := make([]adr.StatusDefinition, 0, len(c.Statuses))
+	aliases := make(map[string]string)
+
+	for _, status := range c.Statuses {
+		definitions = append(definitions, adr.StatusDefinition{
+			Name:     status.Name,
+			Color:    status.Color,
+			Terminal: status.Terminal,
+			Active:   status.Active,
+		})
+
+		for _, alias := range status.Aliases {
+			aliases[alias] = status.Name
+		}
+	}
+
+	initial := c.InitialStatuses
+	if len(initial) == 0 {
+		initial = names
+	}
+
+	transitions := c.Transitions
+	if len(transitions) == 0 {
+		transitions = make(map[string][]string, len(c.Statuses))
+		for _, status := range c.Statuses {
+			if status.Terminal {
+				continue
+			}
+
+			transitions[status.Name] = slices.DeleteFunc(slices.Clone(names), func(s string) bool { return s == status.Name })
+		}
+	}
+
+	lifecycle := adr.NewLifecycle(names, initial, transitions).WithDefinitions(definitions...).WithAliases(aliases)
+	if c.SupersededStatus != "" {
+		lifecycle.WithSuperseded(c.SupersededStatus)
+	}
+
+	return lifecycle
+}
+
+// StatusNames returns the names of the configured statuses, in display order.
+func (c *Config) StatusNames() []string {
+	names := make([]string, 0, len(c.Statuses))
+	for _, status := range c.Statuses {
+		names = append(names, status.Name)
+	}
+
+	return names
+}
+
+// IsRequired reports whether a form field is required.
+func (c *Config) IsRequired(field string) bool {
+	return slices.Contains(c.Required, field)
+}
+
+// Validate checks the config's settings, returning the first problem found.
+func (c *Config) Validate() error {
+	if _, err := render.FormatForName(c.Format); err != nil {
+		return err
+	}
+
+	if c.Template == "" {
+		return globals.ValidationError("template", "empty template")
+	}
+
+	if c.PadWidth < minPadWidth || c.PadWidth > maxPadWidth {
+		return globals.ValidationError("pad-width", fmt.Sprintf("must be between %d and %d", minPadWidth, maxPadWidth))
+	}
+
+	if err := c.validateStatuses(); err != nil {
+		return err
+	}
+
+	for _, field := range c.Required {
+		if !slices.Contains(requirableFields, field) {
+			return globals.ValidationError("required", fmt.Sprintf("unknown field %q", field))
+		}
+	}
+
+	if _, ok := theme.Lookup(c.Theme); !ok {
+		return globals.ValidationError("theme", fmt.Sprintf("unknown theme %q", c.Theme))
+	}
+
+	return nil
+}
+
+// normalizeStatuses lowercases every status and alias, since the lifecycle compares them case-insensitively.
+func (c *Config) normalizeStatuses() {
+	lower := func(statuses []string) []string {
+		lowered := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			lowered = append(lowered, strings.ToLower(strings.TrimSpace(status)))
+		}
+
+		return lowered
+	}
+
+	for i := range c.Statuses {
+		c.Statuses[i].Name = strings.ToLower(strings.TrimSpace(c.Statuses[i].Name))
+		if c.Statuses[i].Aliases != nil {
+			c.Statuses[i].Aliases = lower(c.Statuses[i].Aliases)
+		}
+	}
+
+	if c.InitialStatuses != nil {
+		c.InitialStatuses = lower(c.InitialStatuses)
+	}
+
+	c.SupersededStatus = strings.ToLower(strings.TrimSpace(c.SupersededStatus))
+
+	if c.Transitions != nil {
+		transitions := make(map[string][]string, len(c.Transitions))
+		for from, targets := range c.Transitions {
+			transitions[strings.ToLower(strings.TrimSpace(from))] = lower(targets)
+		}
+
+		c.Transitions = transitions
+	}
+}
+
+// validateStatuses ensures statuses are well-formed and unique, and that initial statuses and transitions only use
+// declared statuses, as does the superseded status. terminal statuses can't have transitions.
+func (c *Config) validateStatuses() error {
+	if len(c.Statuses) == 0 {
+		if len(c.InitialStatuses) > 0 || len(c.Transitions) > 0 {
+			return globals.ValidationError("statuses", "initial-statuses and transitions need statuses")
+		}
+
+		if c.SupersededStatus != "" && c.SupersededStatus != adr.StatusSuperseded {
+			return globals.ValidationError("superseded-status", "a custom superseded-status needs statuses")
+		}
+
+		return nil
+	}
+
+	// names and aliases share a namespace: each must identify exactly one status
+	names := c.StatusNames()
+	seen := make(map[string]bool)
+
+	for _, status := range c.Statuses {
+		if status.Name == "" {
+			return globals.ValidationError("statuses", "empty status name")
+		}
+
+		if status.Color != "" && !colorPattern.MatchString(status.Color) {
+			return globals.ValidationError("statuses", fmt.Sprintf("invalid color %q for status %q", status.Color, status.Name))
+		}
+
+		for _, name := range append([]string{status.Name}, status.Aliases...) {
+			if seen[name] {
+				return globals.ValidationError("statuses", fmt.Sprintf("duplicate status %q", name))
+			}
+
+			seen[name] = true
+		}
+
+		if status.Terminal && len(c.Transitions[status.Name]) > 0 {
+			return globals.ValidationError("transitions", fmt.Sprintf("terminal status %q can't have transitions", status.Name))
+		}
+	}
+
+	if c.SupersededStatus != "" && !slices.Contains(names, c.SupersededStatus) {
+		return globals.ValidationError("superseded-status", fmt.Sprintf("unknown status %q", c.SupersededStatus))
+	}
+
+	for _, status := range c.InitialStatuses {
+		if !slices.Contains(names, status) {
+			return globals.ValidationError("initial-statuses", fmt.Sprintf("unknown status %q", status))
+		}
+	}
+
+	for from, targets := range c.Transitions {
+		for _, status := range append([]string{from}, targets...) {
+			if !slices.Contains(names, status) {
+				return globals.ValidationError("transitions", fmt.Sprintf("unknown status %q", status))
+			}
+		}
+	}
+
+	return nil
+}
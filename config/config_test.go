@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestLoad(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name string
+		// files to write, relative to a temporary repo root holding .git
+		files map[string]string
+		// the directory to load from, relative to the repo root
+		from       string
+		assertFunc func(t *testing.T, root string, cfg *Config, err error)
+	}{
+		{
+			name:  "no config file is the defaults",
+			files: map[string]string{},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, cfg *Config, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, Default(), cfg)
+				assert.Equal(t, adr.DefaultLifecycle(), cfg.Lifecycle())
+			},
+		},
+		{
+			name: "found from a subdirectory, with defaults for missing settings",
+			files: map[string]string{
+				FileName:          "dir: docs/decisions\nformat: asciidoc\npad-width: 3\nrequired: [consequences]\n",
+				"docs/decisions/": "",
+			},
+			from: "docs/decisions",
+			assertFunc: func(t *testing.T, root string, cfg *Config, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(root, FileName), cfg.Path())
+				assert.Equal(t, filepath.Join(root, "docs", "decisions"), cfg.ADRDir())
+				assert.Equal(t, "asciidoc", cfg.Format)
+				assert.Equal(t, "default", cfg.Template)
+				assert.Equal(t, 3, cfg.PadWidth)
+				assert.True(t, cfg.IsRequired(FieldConsequences))
+				assert.False(t, cfg.IsRequired(FieldContext))
+			},
+		},
+		{
+			name:  "the search stops at the repo root",
+			files: map[string]string{FileName: "format: rst\n", "nested/.git/": "", "nested/sub/": ""},
+			from:  "nested/sub",
+			assertFunc: func(t *testing.T, _ string, cfg *Config, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, cfg.Path())
+			},
+		},
+		{
+			name: "custom statuses",
+			files: map[string]string{
+				FileName: "statuses: [Draft, In Review, Accepted]\ninitial-statuses: [draft]\n",
+			},
+			from: ".",
+			assertFunc: func(t *testing.T, _ string, cfg *Config, err error) {
+				require.NoError(t, err)
+
+				lifecycle := cfg.Lifecycle()
+				assert.Equal(t, []string{"draft", "in review", "accepted"}, lifecycle.Statuses())
+				assert.Equal(t, []string{"draft"}, lifecycle.InitialStatuses())
+				assert.Equal(t, []string{"draft", "accepted"}, lifecycle.Next("in review"))
+			},
+		},
+		{
+			name: "status definitions",
+			files: map[string]string{
+				FileName: "statuses:\n" +
+					"  - draft\n" +
+					"  - name: Accepted\n    color: \"#1a7f37\"\n    active: true\n" +
+					"  - name: retired\n    color: \"244\"\n    terminal: true\n    aliases: [Deprecated]\n",
+			},
+			from: ".",
+			assertFunc: func(t *testing.T, _ string, cfg *Config, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"draft", "accepted", "retired"}, cfg.StatusNames())
+
+				lifecycle := cfg.Lifecycle()
+				assert.Equal(t, "#1a7f37", lifecycle.Color("accepted"))
+				assert.True(t, lifecycle.IsActive("accepted"))
+				assert.True(t, lifecycle.IsTerminal("deprecated"))
+				assert.Equal(t, []string{"draft", "retired"}, lifecycle.Next("accepted"))
+				assert.Empty(t, lifecycle.Next("retired"))
+			},
+		},
+		{
+			name: "superseded status",
+			files: map[string]string{
+				FileName: "statuses: [draft, accepted, retired]\nsuperseded-status: Retired\n",
+			},
+			from: ".",
+			assertFunc: func(t *testing.T, _ string, cfg *Config, err error) {
+				require.NoError(t, err)
+
+				lifecycle := cfg.Lifecycle()
+				status, ok := lifecycle.Superseded()
+				assert.True(t, ok)
+				assert.Equal(t, "retired", status)
+				assert.Equal(t, "retired", lifecycle.Label("superceded"))
+			},
+		},
+		{
+			name:  "superseded status must be declared",
+			files: map[string]string{FileName: "statuses: [draft, accepted]\nsuperseded-status: retired\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				var validationError globals.InputValidationError
+				require.ErrorAs(t, err, &validationError)
+				assert.Equal(t, "superseded-status", validationError.Field)
+			},
+		},
+		{
+			name:  "status colors must be hex or ANSI",
+			files: map[string]string{FileName: "statuses:\n  - name: draft\n    color: teal\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `invalid color "teal"`)
+			},
+		},
+		{
+			name:  "statuses and aliases must be unique",
+			files: map[string]string{FileName: "statuses:\n  - draft\n  - name: done\n    aliases: [Draft]\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `duplicate status "draft"`)
+			},
+		},
+		{
+			name: "terminal statuses can't have transitions",
+			files: map[string]string{
+				FileName: "statuses:\n  - draft\n  - name: done\n    terminal: true\ntransitions:\n  done: [draft]\n",
+			},
+			from: ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `terminal status "done"`)
+			},
+		},
+		{
+			name:  "invalid settings are an error",
+			files: map[string]string{FileName: "pad-width: 0\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				var validationError globals.InputValidationError
+				require.ErrorAs(t, err, &validationError)
+				assert.Equal(t, "pad-width", validationError.Field)
+			},
+		},
+		{
+			name:  "transitions must use declared statuses",
+			files: map[string]string{FileName: "statuses: [draft, done]\ntransitions:\n  draft: [shipped]\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `unknown status "shipped"`)
+			},
+		},
+		{
+			name:  "unknown required fields are an error",
+			files: map[string]string{FileName: "required: [title]\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `unknown field "title"`)
+			},
+		},
+		{
+			name:  "unknown themes are an error",
+			files: map[string]string{FileName: "theme: neon\n"},
+			from:  ".",
+			assertFunc: func(t *testing.T, _ string, _ *Config, err error) {
+				require.ErrorContains(t, err, `unknown theme "neon"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o700))
+
+			// names ending in a slash are directories
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				if name[len(name)-1] == '/' {
+					require.NoError(t, os.MkdirAll(path, 0o700))
+
+					continue
+				}
+
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			}
+
+			cfg, err := Load(filepath.Join(root, tt.from))
+			tt.assertFunc(t, root, cfg, err)
+		})
+	}
+}
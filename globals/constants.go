@@ -1,7 +1,22 @@
 package globals
 
-// NumericPadWidth configures the string-width of padded numbers.
-const NumericPadWidth = 4
+// DefaultNumericPadWidth is the string-width of padded numbers, unless a repository configures its own.
+const DefaultNumericPadWidth = 4
+
+// NumericPadWidth configures the string-width of padded numbers. it's set once at startup, from the repository config.
+//
+//nolint:gochecknoglobals // configured once at startup, then read-only
+var NumericPadWidth = DefaultNumericPadWidth
 
 // ListModelWidth assigns a fixed column width to the tui list view.
 const ListModelWidth = 32
+
+// IndexFileName is the default name of the generated ADR index. it's the only non-ADR file allowed in an ADR directory,
+// along with other files holding the index markers.
+const IndexFileName = "README.md"
+
+// markers wrapping the generated index. everything between them is replaced on regeneration.
+const (
+	IndexStartMarker = "<!-- adr-er:index:start -->"
+	IndexEndMarker   = "<!-- adr-er:index:end -->"
+)
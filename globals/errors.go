@@ -37,3 +37,17 @@ type TemplateNotFoundError struct {
 func (err TemplateNotFoundError) Error() string {
 	return fmt.Sprintf("no template found for requested %s format", err.Requested)
 }
+
+// StatusTransitionError is used when a status change isn't allowed by the ADR lifecycle.
+type StatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (err StatusTransitionError) Error() string {
+	if err.From == "" {
+		return fmt.Sprintf("%s is not a valid initial status", err.To)
+	}
+
+	return fmt.Sprintf("illegal status transition from %s to %s", err.From, err.To)
+}
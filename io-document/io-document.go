@@ -101,7 +101,7 @@ func (cd IODocument) DocumentID() string {
 
 // Write attempts to write the document content to a file on disk within the directory <inDir>.
 // It first validates the document before creating the file. Returns an error if validation or writing fails.
-// TODO: Ensure the method does not overwrite existing files.
+// Existing files are never overwritten: writing to a filename that already exists is an error.
 func (cd *IODocument) Write(inDir string) error {
 	var err error
 
@@ -114,9 +114,8 @@ func (cd *IODocument) Write(inDir string) error {
 		return fmt.Errorf("not writing. document validation failed: %w", err)
 	}
 
-	// make the file
-	// TODO: make sure this errors if the file already exists. don't want to force-replace existing files.
-	file, err := os.Create(path.Join(inDir, cd.Filename()))
+	// make the file. O_EXCL refuses to replace an existing file.
+	file, err := os.OpenFile(path.Join(inDir, cd.Filename()), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:mnd,gosec // not magic
 	if err != nil {
 		return fmt.Errorf("could not create file %s: %w", cd.Filename(), err)
 	}
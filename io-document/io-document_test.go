@@ -1,6 +1,8 @@
 package io_document
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -144,6 +146,28 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+// TestWrite guarantees documents are written, and existing files are never replaced.
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	doc, err := NewIODocument(testGetDefaultTemplate(t), "0001: title", []byte("content"))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Write(dir))
+
+	written, err := os.ReadFile(filepath.Join(dir, "0001-title.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(written))
+
+	// a second write must not clobber the first
+	doc.Content = []byte("replaced")
+	require.Error(t, doc.Write(dir))
+
+	written, err = os.ReadFile(filepath.Join(dir, "0001-title.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(written))
+}
+
 // testGetDefaultTemplate returns the default markdown template for testing purposes.
 func testGetDefaultTemplate(t *testing.T) *render.ParsedTemplateFile {
 	t.Helper()
@@ -7,7 +7,21 @@ import (
 	"path/filepath"
 
 	"github.com/therealkevinard/adr-er/commands/create"
+	"github.com/therealkevinard/adr-er/commands/export"
+	"github.com/therealkevinard/adr-er/commands/graph"
+	"github.com/therealkevinard/adr-er/commands/importer"
+	"github.com/therealkevinard/adr-er/commands/index"
+	"github.com/therealkevinard/adr-er/commands/link"
+	"github.com/therealkevinard/adr-er/commands/list"
+	"github.com/therealkevinard/adr-er/commands/search"
+	"github.com/therealkevinard/adr-er/commands/show"
+	"github.com/therealkevinard/adr-er/commands/site"
+	"github.com/therealkevinard/adr-er/commands/status"
+	"github.com/therealkevinard/adr-er/commands/supersede"
 	"github.com/therealkevinard/adr-er/commands/view"
+	"github.com/therealkevinard/adr-er/config"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/theme"
 	"github.com/therealkevinard/adr-er/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -18,6 +32,8 @@ func main() {
 		adrDirectory string
 		// next int sequence. detemined by regex-match on existing filenames in --dir
 		nextSequence int
+		// the repository's conventions, from .adr-er.yaml. flags take precedence over it
+		cfg *config.Config
 	)
 
 	app := &cli.App{
@@ -25,11 +41,24 @@ func main() {
 		Usage: "a friendly little thing for managing architectural decision records",
 		// evaluates environment, assigning adrDirectory and nextSequence
 		Before: func(ctx *cli.Context) error {
+			// load the repository config. a broken config is an error: ignoring it would quietly break conventions.
+			loaded, err := config.Load("")
+			if err != nil {
+				return err
+			}
+
+			cfg = loaded
+			globals.NumericPadWidth = cfg.PadWidth
+
+			if err = theme.Configure(cfg.Theme); err != nil {
+				return err
+			}
+
 			// TODO: these blocks can hold error-cases, but we need file logging to report them.
 
 			// determine correct output dir
 			// don't return on error, just use zero-value (will trigger stdout flag)
-			dir, _ := determineADRDirectory(ctx)
+			dir, _ := determineADRDirectory(ctx, cfg)
 			adrDirectory = dir
 
 			// determine next sequence number
@@ -46,10 +75,12 @@ func main() {
 root directory to store adr files.
 
 if empty: 
-  the application will search for a viable directory according to some conventions.  
-  directories in CWD named "architectural-decision-records", "adr", or ".adr" will be checked. 
+  the dir set in .adr-er.yaml is used, if any.
+  otherwise, the application will search for a viable directory according to some conventions.  
+  an adr-tools .adr-dir file in CWD is honored first.
+  otherwise, directories in CWD named "architectural-decision-records", "adr", or ".adr" will be checked. 
   we will set --dir to the first in the the list that is  
-  a) empty, or b) holds only adr files and optionally subdirectories.
+  a) empty, or b) holds only adr files, optionally the README.md index, and optionally subdirectories.
 if provided:
   the application will not validate contents - we'll trust your judgement
 
@@ -65,8 +96,142 @@ in any case, files will be written to stdout if we have a meaningless/dangerous
 				Aliases:     []string{"c"},
 				Usage:       "create a new adr document",
 				Description: "new is used to create a brand-spankin-new adr document",
+				Flags:       create.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return create.NewCommand(adrDirectory, nextSequence, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:        "status",
+				Aliases:     []string{"s"},
+				Usage:       "change the status of an existing adr document",
+				Description: "moves the adr with the given sequence number to a new status, rewriting only its status in place",
+				ArgsUsage:   "<sequence> <status>",
+				Action: func(ctx *cli.Context) error {
+					return status.NewCommand(adrDirectory, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:        "supersede",
+				Usage:       "replace an existing adr document with a new one",
+				Description: "opens the create form to replace the adr with the given sequence number, linking both documents",
+				ArgsUsage:   "<sequence>",
+				Flags:       supersede.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return supersede.NewCommand(adrDirectory, nextSequence, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:    "link",
+				Aliases: []string{"l"},
+				Usage:   "link two existing adr documents",
+				Description: `adds a typed link between two adrs, rendering it into both documents.
+
+built-in types: amends, clarifies, relates-to, depends-on (or their reverse, eg: amended-by).
+custom types are given as "Forward:Reverse" labels, eg: "Extends:Extended by".
+
+example: adr-er link 12 amends 4`,
+				ArgsUsage: "<source> <type> <target>",
+				Action: func(ctx *cli.Context) error {
+					return link.NewCommand(adrDirectory).Action(ctx)
+				},
+			},
+			{
+				Name:        "list",
+				Aliases:     []string{"ls"},
+				Usage:       "list existing adr documents",
+				Description: "prints every adr in the directory as a table, json, or csv. suitable for scripts and ci",
+				Flags:       list.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return list.NewCommand(adrDirectory, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:        "show",
+				Usage:       "print a single adr document",
+				Description: "renders the adr with the given sequence number or slug. output is plain text when piped",
+				ArgsUsage:   "<sequence or slug>",
+				Flags:       show.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return show.NewCommand(adrDirectory).Action(ctx)
+				},
+			},
+			{
+				Name:    "search",
+				Aliases: []string{"find"},
+				Usage:   "search the titles and bodies of adr documents",
+				Description: `prints adrs matching every term in the query, best matches first, with their matching lines.
+title matches rank above body matches. quote a phrase to match it as a whole.
+
+example: adr-er search kafka "event bus"`,
+				ArgsUsage: "<query>",
+				Flags:     search.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return search.NewCommand(adrDirectory).Action(ctx)
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "import adr documents written by other tools",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "adr-tools",
+						Usage: "import an adr-tools repository",
+						Description: `reads the adrs of an adr-tools project, mapping dates, statuses, and links into adr-er.
+
+<path> is the project root, holding a .adr-dir file or doc/adr, or the adr directory itself.
+the import is previewed until --rewrite is given.
+
+example: adr-er import adr-tools --rewrite .`,
+						ArgsUsage: "<path>",
+						Flags:     importer.Flags(),
+						Action: func(ctx *cli.Context) error {
+							return importer.NewCommand(cfg).Action(ctx)
+						},
+					},
+				},
+			},
+			{
+				Name:        "index",
+				Aliases:     []string{"toc"},
+				Usage:       "write an index of adr documents",
+				Description: "writes a table of every adr to README.md in the adr directory. regenerating replaces only the generated block",
+				Flags:       index.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return index.NewCommand(adrDirectory, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:  "export",
+				Usage: "export every adr document as json or yaml",
+				Description: `writes the whole decision log as one versioned document: sections, metadata, links, path, and a content hash.
+
+the layout is versioned by schema_version. new fields may appear without a version bump.`,
+				Flags: export.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return export.NewCommand(adrDirectory, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:  "graph",
+				Usage: "export the adr relationship graph",
+				Description: `prints every adr as a node, styled by status, and every link as a labeled edge.
+
+mermaid output can be pasted into docs and pull requests. dot output renders with graphviz.
+
+example: adr-er graph --format dot | dot -Tsvg > adr.svg`,
+				Flags: graph.Flags(),
+				Action: func(ctx *cli.Context) error {
+					return graph.NewCommand(adrDirectory, cfg).Action(ctx)
+				},
+			},
+			{
+				Name:        "site",
+				Usage:       "generate a static html site from adr documents",
+				Description: "renders every adr to html, with an index, status filters, and tag pages. the site works fully offline",
+				Flags:       site.Flags(),
 				Action: func(ctx *cli.Context) error {
-					return create.NewCommand(adrDirectory, nextSequence).Action(ctx)
+					return site.NewCommand(adrDirectory, cfg).Action(ctx)
 				},
 			},
 			{
@@ -75,7 +240,7 @@ in any case, files will be written to stdout if we have a meaningless/dangerous
 				Usage:       "view existing ADR history",
 				Description: "runs a tui application for reading historical ADRs",
 				Action: func(ctx *cli.Context) error {
-					return view.NewCommand(adrDirectory).Action(ctx)
+					return view.NewCommand(adrDirectory, cfg).Action(ctx)
 				},
 			},
 		},
@@ -86,18 +251,22 @@ in any case, files will be written to stdout if we have a meaningless/dangerous
 	}
 }
 
-// determineADRDirectory determines the correct root/output directory for ADR files
+// determineADRDirectory determines the correct root/output directory for ADR files: the --dir flag, then the config's
+// dir, then the conventions in utils.LocateADRDirectory.
 // returns the normalized absolute path.
-func determineADRDirectory(ctx *cli.Context) (string, error) {
+func determineADRDirectory(ctx *cli.Context, cfg *config.Config) (string, error) {
 	var (
 		err       error  // an error
 		outputDir string // normalized dir
 		dir       string // intermediate dir var, from either flag or LocateADRDirectory
 	)
 
-	// init dir based on --dir flag: if provided, use it; if not use the conventions codified in utils.LocateADRDirectory
+	// init dir based on --dir flag: if provided, use it; if not use the config's, or the conventions codified in
+	// utils.LocateADRDirectory
 	if userDir := ctx.String("dir"); userDir != "" {
 		dir = userDir
+	} else if configDir := cfg.ADRDir(); configDir != "" {
+		dir = configDir
 	} else {
 		dir, err = utils.LocateADRDirectory("")
 		if err != nil {
@@ -0,0 +1,23 @@
+{{- with .FrontMatter}}////
+{{trim .}}
+////
+
+{{end}}= {{.SequencedTitle}}
+
+== Status: {{.Status}}
+
+== Context
+{{.Context}}
+
+== Decision
+{{.Decision}}
+
+== Consequences
+{{.Consequences}}
+{{- with .Links}}
+
+== Links
+{{- range .}}
+* {{.DisplayLabel}} {{adrLink .}}
+{{- end}}
+{{- end}}
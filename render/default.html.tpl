@@ -0,0 +1,34 @@
+{{- frontMatter .}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.SequencedTitle}}</title>
+<style>
+article { max-width: 46rem; margin: 2rem auto; font-family: system-ui, sans-serif; line-height: 1.5; }
+pre { overflow-x: auto; padding: 0.75rem; background: #f5f5f5; }
+</style>
+</head>
+<body>
+<article>
+<h1>{{.SequencedTitle}}</h1>
+
+<h2>Status: {{.Status}}</h2>
+
+<h2>Context</h2>
+{{markdown .Context}}
+<h2>Decision</h2>
+{{markdown .Decision}}
+<h2>Consequences</h2>
+{{markdown .Consequences}}
+{{- with .Links}}
+<h2>Links</h2>
+<ul>
+{{- range .}}
+<li>{{.DisplayLabel}} {{adrLink .}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</article>
+</body>
+</html>
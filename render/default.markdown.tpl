@@ -1,4 +1,4 @@
-{{.SequencedTitle}}
+{{.FrontMatter}}{{.SequencedTitle}}
 ---
 
 ## Status: {{.Status}}
@@ -11,3 +11,10 @@
 
 ## Consequences
 {{.Consequences}}
+{{- with .Links}}
+
+## Links
+{{- range .}}
+- {{.Markdown}}
+{{- end}}
+{{- end}}
@@ -0,0 +1,24 @@
+{{- frontMatter .}}:PROPERTIES:
+:STATUS: {{.Status}}
+{{- with .Tags}}
+:TAGS: {{join ", " .}}
+{{- end}}
+:END:
+#+TITLE: {{.SequencedTitle}}
+#+DATE: {{.Created | date "<2006-01-02 Mon>"}}
+
+* Context
+{{.Context}}
+
+* Decision
+{{.Decision}}
+
+* Consequences
+{{.Consequences}}
+{{- with .Links}}
+
+* Links
+{{- range .}}
+- {{.DisplayLabel}} {{adrLink .}}
+{{- end}}
+{{- end}}
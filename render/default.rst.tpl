@@ -0,0 +1,30 @@
+{{- with .FrontMatter}}..
+{{trim . | indent 3}}
+
+{{end}}{{underline "=" .SequencedTitle}}
+{{.SequencedTitle}}
+{{underline "=" .SequencedTitle}}
+
+Status
+------
+{{.Status}}
+
+Context
+-------
+{{.Context}}
+
+Decision
+--------
+{{.Decision}}
+
+Consequences
+------------
+{{.Consequences}}
+{{- with .Links}}
+
+Links
+-----
+{{- range .}}
+- {{.DisplayLabel}} {{adrLink .}}
+{{- end}}
+{{- end}}
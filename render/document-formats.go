@@ -1,6 +1,11 @@
 package render
 
-import "github.com/therealkevinard/adr-er/globals"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+)
 
 var _ globals.Validator = (*DocumentFormat)(nil)
 
@@ -32,6 +37,14 @@ func (df DocumentFormat) Extension() string {
 const (
 	// DocumentFormatMarkdown represents a markdown document format.
 	DocumentFormatMarkdown DocumentFormat = "markdown"
+	// DocumentFormatAsciiDoc represents an asciidoc document format, as used by asciidoctor and antora.
+	DocumentFormatAsciiDoc DocumentFormat = "asciidoc"
+	// DocumentFormatRST represents a restructuredtext document format, as used by sphinx.
+	DocumentFormatRST DocumentFormat = "rst"
+	// DocumentFormatOrg represents an emacs org-mode document format.
+	DocumentFormatOrg DocumentFormat = "org"
+	// DocumentFormatHTML represents a self-contained html document format. its templates are rendered with html/template.
+	DocumentFormatHTML DocumentFormat = "html"
 )
 
 // supportedFormats registers a map of supported formats to their fs extension.
@@ -39,4 +52,36 @@ const (
 //nolint:gochecknoglobals // this is a package-internal global by design
 var supportedFormats = map[DocumentFormat]string{
 	DocumentFormatMarkdown: "md",
+	DocumentFormatAsciiDoc: "adoc",
+	DocumentFormatRST:      "rst",
+	DocumentFormatOrg:      "org",
+	DocumentFormatHTML:     "html",
+}
+
+// FormatForExtension returns the DocumentFormat registered for a file extension.
+// the extension may be given with or without its leading dot. returns false if no format uses the extension.
+func FormatForExtension(ext string) (DocumentFormat, bool) {
+	ext = strings.TrimPrefix(ext, ".")
+
+	for format, formatExt := range supportedFormats {
+		if strings.EqualFold(formatExt, ext) {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// FormatForName returns the DocumentFormat for user input, which may be either the format's name or its extension,
+// eg: "asciidoc" or "adoc". Returns a validation error if no format matches.
+func FormatForName(name string) (DocumentFormat, error) {
+	if format := DocumentFormat(strings.ToLower(strings.TrimSpace(name))); format.Validate() == nil {
+		return format, nil
+	}
+
+	if format, ok := FormatForExtension(name); ok {
+		return format, nil
+	}
+
+	return "", globals.ValidationError("format", fmt.Sprintf("unsupported format %q", name))
 }
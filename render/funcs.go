@@ -0,0 +1,224 @@
+package render
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/therealkevinard/adr-er/utils"
+)
+
+// FuncMap returns the functions available to every ADR template, embedded or custom.
+// Arguments are ordered so the value comes last, which lets them be chained in pipelines, eg:
+// `{{.Created | date "2006-01-02"}}` or `{{.Authors | join ", " | default "nobody"}}`.
+//
+//   - date LAYOUT TIME: formats a time with a go layout. zero times render as an empty string
+//   - slug TEXT: slugifies text, as filenames are
+//   - pad WIDTH NUMBER: pads a number with leading zeros
+//   - wrap WIDTH TEXT: wraps text at WIDTH columns, keeping existing line breaks
+//   - indent COUNT TEXT: indents each non-blank line by COUNT spaces
+//   - default FALLBACK VALUE: returns VALUE, or FALLBACK if VALUE is empty
+//   - join SEPARATOR LIST: joins a list of strings
+//   - upper TEXT, lower TEXT, trim TEXT: change case, or trim surrounding whitespace
+//   - link TEXT TARGET: renders a link in the template's format
+//   - section HEADING BODY: renders a section, or nothing at all if BODY is blank. see Section
+//   - markdown TEXT: converts markdown to html, for html templates
+//   - underline CHAR TEXT: repeats CHAR as many times as TEXT is long, for restructuredtext headings
+func FuncMap(format DocumentFormat) template.FuncMap {
+	return template.FuncMap{
+		"date":      formatDate,
+		"slug":      utils.Slugify,
+		"pad":       func(width, value int) string { return utils.PadValue(value, width) },
+		"wrap":      wrap,
+		"indent":    indent,
+		"default":   defaultValue,
+		"join":      func(sep string, values []string) string { return strings.Join(values, sep) },
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"trim":      strings.TrimSpace,
+		"link":      func(text, target string) any { return Trusted(format, LinkMarkup(format, text, target)) },
+		"section":   func(heading, body string) any { return Trusted(format, Section(format, heading, body)) },
+		"markdown":  func(text string) (any, error) { return markdownFunc(format, text) },
+		"underline": Underline,
+	}
+}
+
+// Section renders a second-level section in the given format, or an empty string if body is blank.
+// the section opens with a blank line and closes with a newline, so sections can follow each other, eg:
+//
+//	# {{.Title}}
+//	{{section "Context" .Context -}}
+//	{{section "Decision" .Decision -}}
+func Section(format DocumentFormat, heading, body string) string {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return ""
+	}
+
+	switch format {
+	case DocumentFormatMarkdown:
+		return fmt.Sprintf("\n## %s\n\n%s\n", heading, body)
+	case DocumentFormatAsciiDoc:
+		return fmt.Sprintf("\n== %s\n\n%s\n", heading, body)
+	case DocumentFormatRST:
+		return fmt.Sprintf("\n%s\n%s\n\n%s\n", heading, Underline(RSTSectionUnderline, heading), body)
+	case DocumentFormatOrg:
+		return fmt.Sprintf("\n* %s\n\n%s\n", heading, body)
+	case DocumentFormatHTML:
+		return htmlSection(heading, body)
+	default:
+		return fmt.Sprintf("\n%s\n\n%s\n", heading, body)
+	}
+}
+
+// LinkMarkup renders a link to target in the given format. without a target, only the text is rendered.
+// asciidoc links to other asciidoc documents are cross references, which asciidoctor and antora both resolve.
+// html links are escaped, and safe to use as markup.
+func LinkMarkup(format DocumentFormat, text, target string) string {
+	if target == "" {
+		return Escape(format, text)
+	}
+
+	switch format {
+	case DocumentFormatMarkdown:
+		return fmt.Sprintf("[%s](%s)", text, target)
+	case DocumentFormatAsciiDoc:
+		if strings.HasSuffix(target, "."+DocumentFormatAsciiDoc.Extension()) {
+			return fmt.Sprintf("xref:%s[%s]", target, text)
+		}
+
+		return fmt.Sprintf("link:%s[%s]", target, text)
+	case DocumentFormatRST:
+		// sphinx resolves links to other documents by name, without the extension
+		if docName, isDoc := strings.CutSuffix(target, "."+DocumentFormatRST.Extension()); isDoc {
+			return fmt.Sprintf(":doc:`%s <%s>`", text, docName)
+		}
+
+		return fmt.Sprintf("`%s <%s>`__", text, target)
+	case DocumentFormatOrg:
+		// relative paths are file links, which org opens in place
+		if !strings.Contains(target, "://") {
+			target = "file:" + target
+		}
+
+		return fmt.Sprintf("[[%s][%s]]", target, text)
+	case DocumentFormatHTML:
+		return fmt.Sprintf(`<a href="%s">%s</a>`, Escape(format, target), Escape(format, text))
+	default:
+		return fmt.Sprintf("%s (%s)", text, target)
+	}
+}
+
+// markdownFunc converts markdown text to html, trusted as markup in html templates.
+func markdownFunc(format DocumentFormat, text string) (any, error) {
+	converted, err := MarkdownHTML(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return Trusted(format, converted), nil
+}
+
+// restructuredtext heading adornments, as written by the default template.
+const (
+	// RSTTitleAdornment over- and underlines the document title
+	RSTTitleAdornment = "="
+	// RSTSectionUnderline underlines each section heading
+	RSTSectionUnderline = "-"
+)
+
+// Underline repeats char once per display column of text, so restructuredtext heading adornments match their heading.
+// wide characters, eg: "決定", take two columns each.
+func Underline(char, text string) string {
+	return strings.Repeat(char, runewidth.StringWidth(text))
+}
+
+// formatDate formats t with layout, or returns an empty string for the zero time.
+func formatDate(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
+
+// defaultValue returns value, or fallback if value is empty: nil, blank text, an empty list, or a zero value.
+func defaultValue(fallback, value any) any {
+	if value == nil {
+		return fallback
+	}
+
+	v := reflect.ValueOf(value)
+
+	//nolint:exhaustive // everything else falls back to its zero value
+	switch v.Kind() {
+	case reflect.String:
+		if strings.TrimSpace(v.String()) == "" {
+			return fallback
+		}
+	case reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return fallback
+		}
+	default:
+		if v.IsZero() {
+			return fallback
+		}
+	}
+
+	return value
+}
+
+// wrap breaks text into lines of at most width columns, at spaces. existing line breaks and leading indentation are
+// kept, and words longer than width are left whole.
+func wrap(width int, text string) string {
+	if width <= 0 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		var wrapped strings.Builder
+
+		leading := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		wrapped.WriteString(leading)
+		column := len(leading)
+
+		for j, word := range strings.Fields(line) {
+			switch {
+			case j == 0:
+			case column+1+len(word) > width:
+				wrapped.WriteString("\n")
+
+				column = 0
+			default:
+				wrapped.WriteString(" ")
+				column++
+			}
+
+			wrapped.WriteString(word)
+			column += len(word)
+		}
+
+		lines[i] = wrapped.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// indent prefixes each non-blank line of text with count spaces.
+func indent(count int, text string) string {
+	prefix := strings.Repeat(" ", count)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
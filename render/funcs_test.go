@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncMap(t *testing.T) {
+	data := map[string]any{
+		"Created":  time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		"Zero":     time.Time{},
+		"Title":    "Use Go, Mostly!",
+		"Sequence": 7,
+		"Authors":  []string{"alice", "bob"},
+		"None":     []string{},
+		"Blank":    "  ",
+		"Text":     "one two three four",
+	}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: `{{.Created | date "2006-01-02"}}`, expected: "2024-09-01"},
+		{template: `{{.Zero | date "2006-01-02"}}`, expected: ""},
+		{template: `{{.Title | slug}}`, expected: "use-go-mostly"},
+		{template: `{{.Sequence | pad 4}}`, expected: "0007"},
+		{template: `{{.Text | wrap 9}}`, expected: "one two\nthree\nfour"},
+		{template: `{{"  one two three" | wrap 9}}`, expected: "  one two\nthree"},
+		{template: `{{"a\n\nb" | indent 2}}`, expected: "  a\n\n  b"},
+		{template: `{{.Blank | default "n/a"}}`, expected: "n/a"},
+		{template: `{{.None | default "nobody"}}`, expected: "nobody"},
+		{template: `{{.Sequence | default 1}}`, expected: "7"},
+		{template: `{{.Authors | join ", "}}`, expected: "alice, bob"},
+		{template: `{{.None | join ", " | default "nobody"}}`, expected: "nobody"},
+		{template: `{{.Title | upper}} {{.Title | lower}} [{{.Blank | trim}}]`, expected: "USE GO, MOSTLY! use go, mostly! []"},
+		{template: `{{link "ADR 1" "0001-use-go.md"}}`, expected: "[ADR 1](0001-use-go.md)"},
+		{template: `{{link "ADR 1" ""}}`, expected: "ADR 1"},
+		{template: `{{underline "-" "Décision"}}`, expected: "--------"},
+		{template: `{{underline "=" "採用決定"}}`, expected: "========"},
+		{template: "# T\n{{section \"Context\" .Text -}}\n{{section \"Empty\" .Blank -}}\n", expected: "# T\n\n## Context\n\none two three four\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tpl, err := template.New("test").Funcs(FuncMap(DocumentFormatMarkdown)).Parse(tt.template)
+			require.NoError(t, err)
+
+			var rendered bytes.Buffer
+			require.NoError(t, tpl.Execute(&rendered, data))
+			assert.Equal(t, tt.expected, rendered.String())
+		})
+	}
+}
+
+func TestLinkMarkup(t *testing.T) {
+	assert.Equal(t, "[0001: Go](0001-go.md)", LinkMarkup(DocumentFormatMarkdown, "0001: Go", "0001-go.md"))
+	assert.Equal(t, "xref:0001-go.adoc[0001: Go]", LinkMarkup(DocumentFormatAsciiDoc, "0001: Go", "0001-go.adoc"))
+	assert.Equal(t, "link:0001-go.md[0001: Go]", LinkMarkup(DocumentFormatAsciiDoc, "0001: Go", "0001-go.md"))
+	assert.Equal(t, "0001: Go", LinkMarkup(DocumentFormatAsciiDoc, "0001: Go", ""))
+	assert.Equal(t, ":doc:`0001: Go <0001-go>`", LinkMarkup(DocumentFormatRST, "0001: Go", "0001-go.rst"))
+	assert.Equal(t, "`0001: Go <0001-go.md>`__", LinkMarkup(DocumentFormatRST, "0001: Go", "0001-go.md"))
+	assert.Equal(t, "[[file:0001-go.org][0001: Go]]", LinkMarkup(DocumentFormatOrg, "0001: Go", "0001-go.org"))
+	assert.Equal(t, "[[https://adr.github.io][MADR]]", LinkMarkup(DocumentFormatOrg, "MADR", "https://adr.github.io"))
+	assert.Equal(t, `<a href="0001-go.html">0001: Go &amp; More</a>`, LinkMarkup(DocumentFormatHTML, "0001: Go & More", "0001-go.html"))
+	assert.Equal(t, "a &lt;b&gt;", LinkMarkup(DocumentFormatHTML, "a <b>", ""))
+}
@@ -0,0 +1,217 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+// GraphFormat is a typed const for supported graph output formats.
+type GraphFormat string
+
+// Graph format enum.
+const (
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatMermaid GraphFormat = "mermaid"
+)
+
+// Graph is a format-agnostic model of the relationships between documents.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode is a single document in the graph.
+type GraphNode struct {
+	// ID uniquely identifies the node. it's referenced by edges.
+	ID string
+	// Label is the node's display text
+	Label string
+	// Status picks the node's style. unknown statuses are drawn in Color, or plain without one.
+	Status string
+	// Color is the status's display color. only hex colors are used: graphs can't draw ANSI colors.
+	Color string
+	// Terminal statuses are drawn dashed
+	Terminal bool
+}
+
+// GraphEdge is a directed, labeled relationship between two nodes.
+type GraphEdge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// nodeStyle is how a node is drawn for its status.
+type nodeStyle struct {
+	fill   string
+	stroke string
+	dashed bool
+}
+
+// statusStyles maps well-known statuses to their node styles.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var statusStyles = map[string]nodeStyle{
+	"proposed":   {fill: "#fff8e1", stroke: "#f9a825", dashed: false},
+	"accepted":   {fill: "#e8f5e9", stroke: "#2e7d32", dashed: false},
+	"rejected":   {fill: "#ffebee", stroke: "#c62828", dashed: false},
+	"deprecated": {fill: "#eeeeee", stroke: "#757575", dashed: true},
+	"superseded": {fill: "#eeeeee", stroke: "#9e9e9e", dashed: true},
+}
+
+// defaultNodeStyle is used for statuses without a style of their own.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var defaultNodeStyle = nodeStyle{fill: "#ffffff", stroke: "#424242", dashed: false}
+
+// nonIdentifier matches runs of characters that can't appear in a mermaid class name.
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// Render draws the graph in the given format.
+func (g *Graph) Render(format GraphFormat) (string, error) {
+	switch format {
+	case GraphFormatDOT:
+		return g.dot(), nil
+	case GraphFormatMermaid:
+		return g.mermaid(), nil
+	default:
+		return "", globals.ValidationError("format", fmt.Sprintf("unsupported graph format %q", format))
+	}
+}
+
+// dot draws the graph as a graphviz digraph.
+func (g *Graph) dot() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph adr {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	builder.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	if len(g.Nodes) > 0 {
+		builder.WriteString("\n")
+	}
+
+	for _, node := range g.Nodes {
+		style := styleFor(node)
+
+		nodeStyles := "rounded,filled"
+		if style.dashed {
+			nodeStyles += ",dashed"
+		}
+
+		fmt.Fprintf(&builder, "  %s [label=%s, style=%s, fillcolor=%s, color=%s];\n",
+			dotQuote(node.ID), `"`+nodeLabel(node, dotEscape, `\n`)+`"`, dotQuote(nodeStyles),
+			dotQuote(style.fill), dotQuote(style.stroke),
+		)
+	}
+
+	if len(g.Edges) > 0 {
+		builder.WriteString("\n")
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&builder, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Label))
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// mermaid draws the graph as a mermaid flowchart.
+func (g *Graph) mermaid() string {
+	var builder strings.Builder
+
+	builder.WriteString("flowchart LR\n")
+
+	// one class per status, declared once
+	classes := make(map[string]bool)
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&builder, "  %s[\"%s\"]\n", mermaidID(node.ID), nodeLabel(node, mermaidEscape, "<br/>"))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&builder, "  %s -->|\"%s\"| %s\n", mermaidID(edge.From), mermaidEscape(edge.Label), mermaidID(edge.To))
+	}
+
+	for _, node := range g.Nodes {
+		class := mermaidClass(node.Status)
+
+		if !classes[class] {
+			classes[class] = true
+
+			style := styleFor(node)
+			definition := fmt.Sprintf("fill:%s,stroke:%s", style.fill, style.stroke)
+
+			if style.dashed {
+				definition += ",stroke-dasharray:5 5"
+			}
+
+			fmt.Fprintf(&builder, "  classDef %s %s\n", class, definition)
+		}
+
+		fmt.Fprintf(&builder, "  class %s %s\n", mermaidID(node.ID), class)
+	}
+
+	return builder.String()
+}
+
+// styleFor returns the node style for a node's status.
+// well-known statuses have their own style. others are outlined in their color, and dashed when terminal.
+func styleFor(node GraphNode) nodeStyle {
+	if style, ok := statusStyles[strings.ToLower(node.Status)]; ok {
+		return style
+	}
+
+	style := defaultNodeStyle
+	if strings.HasPrefix(node.Color, "#") {
+		style.stroke = node.Color
+	}
+
+	style.dashed = node.Terminal
+
+	return style
+}
+
+// nodeLabel is the node's escaped label with its status on a second line, joined by the format's line break.
+func nodeLabel(node GraphNode, escape func(string) string, lineBreak string) string {
+	if node.Status == "" {
+		return escape(node.Label)
+	}
+
+	return escape(node.Label) + lineBreak + "(" + escape(node.Status) + ")"
+}
+
+// dotQuote renders value as a quoted dot string.
+func dotQuote(value string) string {
+	return `"` + dotEscape(value) + `"`
+}
+
+// dotEscape keeps backslashes and quotes from being read as dot syntax.
+func dotEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// mermaidID prefixes id so it's always a valid mermaid node id.
+func mermaidID(id string) string {
+	return "adr" + nonIdentifier.ReplaceAllString(id, "_")
+}
+
+// mermaidClass names the class for a status.
+func mermaidClass(status string) string {
+	if status == "" {
+		return "status_none"
+	}
+
+	return "status_" + nonIdentifier.ReplaceAllString(strings.ToLower(status), "_")
+}
+
+// mermaidEscape keeps quotes and angle brackets from being read as mermaid or html syntax.
+func mermaidEscape(value string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(value)
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphRender(t *testing.T) {
+	graph := Graph{
+		Nodes: []GraphNode{
+			{ID: "0001", Label: `0001: Use "Kafka"`, Status: "superseded"},
+			{ID: "0002", Label: "0002: Use <NATS>", Status: "accepted"},
+			{ID: "0003", Label: "0003", Status: ""},
+			{ID: "0004", Label: "0004", Status: "retired", Color: "#8250df", Terminal: true},
+		},
+		Edges: []GraphEdge{
+			{From: "0002", To: "0001", Label: "Supersedes"},
+			{From: "0002", To: "0003", Label: "Relates to"},
+		},
+	}
+
+	dot, err := graph.Render(GraphFormatDOT)
+	require.NoError(t, err)
+	assert.Equal(t, `digraph adr {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="Helvetica"];
+  edge [fontname="Helvetica", fontsize=10];
+
+  "0001" [label="0001: Use \"Kafka\"\n(superseded)", style="rounded,filled,dashed", fillcolor="#eeeeee", color="#9e9e9e"];
+  "0002" [label="0002: Use <NATS>\n(accepted)", style="rounded,filled", fillcolor="#e8f5e9", color="#2e7d32"];
+  "0003" [label="0003", style="rounded,filled", fillcolor="#ffffff", color="#424242"];
+  "0004" [label="0004\n(retired)", style="rounded,filled,dashed", fillcolor="#ffffff", color="#8250df"];
+
+  "0002" -> "0001" [label="Supersedes"];
+  "0002" -> "0003" [label="Relates to"];
+}
+`, dot)
+
+	mermaid, err := graph.Render(GraphFormatMermaid)
+	require.NoError(t, err)
+	assert.Equal(t, `flowchart LR
+  adr0001["0001: Use #quot;Kafka#quot;<br/>(superseded)"]
+  adr0002["0002: Use #lt;NATS#gt;<br/>(accepted)"]
+  adr0003["0003"]
+  adr0004["0004<br/>(retired)"]
+  adr0002 -->|"Supersedes"| adr0001
+  adr0002 -->|"Relates to"| adr0003
+  classDef status_superseded fill:#eeeeee,stroke:#9e9e9e,stroke-dasharray:5 5
+  class adr0001 status_superseded
+  classDef status_accepted fill:#e8f5e9,stroke:#2e7d32
+  class adr0002 status_accepted
+  classDef status_none fill:#ffffff,stroke:#424242
+  class adr0003 status_none
+  classDef status_retired fill:#ffffff,stroke:#8250df,stroke-dasharray:5 5
+  class adr0004 status_retired
+`, mermaid)
+
+	_, err = graph.Render("svg")
+	require.Error(t, err)
+}
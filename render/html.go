@@ -0,0 +1,116 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// markdownConverter converts markdown fields for html documents. raw html in the markdown is escaped, never passed
+// through, so user text like "Result<T>" reads as typed and can't inject markup.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var markdownConverter = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(renderer.WithNodeRenderers(util.Prioritized(escapedHTMLRenderer{}, escapedHTMLPriority))),
+)
+
+// escapedHTMLPriority ranks escapedHTMLRenderer ahead of goldmark's html renderer, which omits raw html.
+const escapedHTMLPriority = 100
+
+// escapedHTMLRenderer renders raw html as escaped text: inline tags in place, and html blocks as paragraphs.
+type escapedHTMLRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r escapedHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindRawHTML, r.renderRawHTML)
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+}
+
+// renderRawHTML escapes an inline html tag, eg: the "<T>" in "Result<T>".
+func (r escapedHTMLRenderer) renderRawHTML(
+	w util.BufWriter, source []byte, node ast.Node, entering bool,
+) (ast.WalkStatus, error) {
+	if entering {
+		segments := node.(*ast.RawHTML).Segments //nolint:forcetypeassert // registered for this kind only
+		for i := range segments.Len() {
+			segment := segments.At(i)
+			_, _ = w.WriteString(htmltemplate.HTMLEscapeString(string(segment.Value(source))))
+		}
+	}
+
+	return ast.WalkSkipChildren, nil
+}
+
+// renderHTMLBlock escapes a block of html into a paragraph.
+func (r escapedHTMLRenderer) renderHTMLBlock(
+	w util.BufWriter, source []byte, node ast.Node, entering bool,
+) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	block := node.(*ast.HTMLBlock) //nolint:forcetypeassert // registered for this kind only
+
+	var text bytes.Buffer
+	for i := range block.Lines().Len() {
+		line := block.Lines().At(i)
+		text.Write(line.Value(source))
+	}
+
+	if block.HasClosure() {
+		text.Write(block.ClosureLine.Value(source))
+	}
+
+	_, _ = w.WriteString("<p>" + htmltemplate.HTMLEscapeString(strings.TrimRight(text.String(), "\n")) + "</p>\n")
+
+	return ast.WalkSkipChildren, nil
+}
+
+// MarkdownHTML converts markdown text to html.
+func MarkdownHTML(text string) (string, error) {
+	var converted bytes.Buffer
+	if err := markdownConverter.Convert([]byte(text), &converted); err != nil {
+		return "", fmt.Errorf("error converting markdown: %w", err)
+	}
+
+	return converted.String(), nil
+}
+
+// Escape escapes text for literal use in a document of the given format. only html needs escaping.
+func Escape(format DocumentFormat, text string) string {
+	if format == DocumentFormatHTML {
+		return htmltemplate.HTMLEscapeString(text)
+	}
+
+	return text
+}
+
+// Trusted marks markup built by adr-er as safe for the given format, so html templates don't escape it a second time.
+// markup for other formats is returned as-is.
+func Trusted(format DocumentFormat, markup string) any {
+	if format == DocumentFormatHTML {
+		//nolint:gosec // callers only pass markup they've escaped themselves
+		return htmltemplate.HTML(markup)
+	}
+
+	return markup
+}
+
+// htmlSection renders a section of an html document, converting its markdown body.
+// bodies that fail to convert are shown preformatted instead.
+func htmlSection(heading, body string) string {
+	converted, err := MarkdownHTML(body)
+	if err != nil {
+		converted = "<pre>" + htmltemplate.HTMLEscapeString(body) + "</pre>\n"
+	}
+
+	return fmt.Sprintf("\n<h2>%s</h2>\n%s", htmltemplate.HTMLEscapeString(heading), strings.TrimRight(converted, "\n")+"\n")
+}
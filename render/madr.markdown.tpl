@@ -0,0 +1,26 @@
+{{.FrontMatter}}# {{.Title}}
+
+## Context and Problem Statement
+
+{{.Context}}
+{{with .ConsideredOptions}}
+## Considered Options
+{{range .}}
+* {{.}}
+{{- end}}
+{{end}}
+## Decision Outcome
+
+{{.Decision}}
+
+### Consequences
+
+{{.Consequences}}
+{{section "Pros and Cons of the Options" .ProsAndCons -}}
+{{- with .Links}}
+
+## Links
+{{- range .}}
+- {{.Markdown}}
+{{- end}}
+{{- end}}
@@ -2,7 +2,11 @@ package render
 
 import (
 	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
 	"strings"
+	"text/template"
 
 	"github.com/therealkevinard/adr-er/globals"
 )
@@ -45,14 +49,41 @@ func (t *ParsedTemplateFile) Validate() error {
 	return nil
 }
 
-// parseTemplate parses a template file name according to the `{name}.{format}.tpl` naming convention.
-// Returns a ParsedTemplateFile with extracted metadata and content, or nil if parsing fails.
-func parseTemplate(filename string) *ParsedTemplateFile {
+// Executor renders a prepared template. text/template and html/template templates both satisfy it.
+type Executor interface {
+	Execute(wr io.Writer, data any) error
+}
+
+// Prepare parses the template's content with the engine its format needs, making funcs available to it.
+// html templates use html/template, so values are escaped for the context they're rendered in. every other format is
+// plain text, and uses text/template.
+func (t *ParsedTemplateFile) Prepare(funcs map[string]any) (Executor, error) {
+	if t.Format == DocumentFormatHTML {
+		tpl, err := htmltemplate.New(t.ID).Funcs(funcs).Parse(string(t.Content))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template %s: %w", t.Name, err)
+		}
+
+		return tpl, nil
+	}
+
+	tpl, err := template.New(t.ID).Funcs(funcs).Parse(string(t.Content))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template %s: %w", t.Name, err)
+	}
+
+	return tpl, nil
+}
+
+// parseTemplate parses a template file name according to the `{name}.{format}.tpl` naming convention, reading its
+// content from fsys.
+// Returns nil for files that don't follow the convention, or an error if a template can't be read or is invalid.
+func parseTemplate(fsys fs.FS, filename string) (*ParsedTemplateFile, error) {
 	parts := strings.Split(filename, ".")
 	// exactly 3 parts are expected
 	//nolint:mnd // this isn't magic, it's from the regex capture
-	if len(parts) != 3 {
-		return nil
+	if len(parts) != 3 || parts[2] != "tpl" {
+		return nil, nil //nolint:nilnil // not a template
 	}
 
 	parsed := &ParsedTemplateFile{
@@ -63,15 +94,13 @@ func parseTemplate(filename string) *ParsedTemplateFile {
 	}
 
 	var err error
-	if parsed.Content, err = TemplateFS.ReadFile(parsed.Name); err != nil {
-		// error reading, continue
-		return nil
+	if parsed.Content, err = fs.ReadFile(fsys, parsed.Name); err != nil {
+		return nil, fmt.Errorf("error reading template %s: %w", filename, err)
 	}
 
-	// invalid, continue
 	if err = parsed.Validate(); err != nil {
-		return nil
+		return nil, fmt.Errorf("invalid template %s: %w", filename, err)
 	}
 
-	return parsed
+	return parsed, nil
 }
@@ -3,6 +3,10 @@ package render
 import (
 	"embed"
 	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/therealkevinard/adr-er/globals"
@@ -25,11 +29,48 @@ func (e TemplateNotFoundError) Error() string {
 //go:embed *.tpl
 var TemplateFS embed.FS
 
+// LocalTemplatesDir is the directory, inside an ADR directory, holding a repository's own templates.
+const LocalTemplatesDir = ".templates"
+
 // ListTemplates reads all embedded template files and returns a map where keys are template file paths and values are
 // parsed template specifications.
 // The parsed templates contain metadata and content for each file.
-func ListTemplates() (map[string]*ParsedTemplateFile, error) {
-	tpls, err := TemplateFS.ReadDir(".")
+// Templates found in dirs, following the same naming convention, are layered on top in order, taking precedence over
+// embedded ones with the same name. Missing dirs are skipped, but user templates that fail validation are an error.
+func ListTemplates(dirs ...string) (map[string]*ParsedTemplateFile, error) {
+	index, err := listTemplates(TemplateFS)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range dirs {
+		if info, statErr := os.Stat(dir); dir == "" || statErr != nil || !info.IsDir() {
+			continue
+		}
+
+		local, localErr := listTemplates(os.DirFS(dir))
+		if localErr != nil {
+			return nil, fmt.Errorf("error loading templates from %s: %w", dir, localErr)
+		}
+
+		maps.Copy(index, local)
+	}
+
+	return index, nil
+}
+
+// LocalTemplateDir returns the templates directory for an ADR directory, or empty if there's no ADR directory.
+func LocalTemplateDir(adrDir string) string {
+	if adrDir == "" {
+		return ""
+	}
+
+	return filepath.Join(adrDir, LocalTemplatesDir)
+}
+
+// listTemplates parses every template file at the root of fsys.
+func listTemplates(fsys fs.FS) (map[string]*ParsedTemplateFile, error) {
+	tpls, err := fs.ReadDir(fsys, ".")
 	if err != nil {
 		return nil, fmt.Errorf("error listing templates: %w", err)
 	}
@@ -43,7 +84,11 @@ func ListTemplates() (map[string]*ParsedTemplateFile, error) {
 		}
 
 		// parse the name, getting important metadata and its content
-		parsed := parseTemplate(tpl.Name())
+		parsed, parseErr := parseTemplate(fsys, tpl.Name())
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
 		if parsed == nil {
 			continue
 		}
@@ -54,20 +99,37 @@ func ListTemplates() (map[string]*ParsedTemplateFile, error) {
 	return index, nil
 }
 
+// Built-in template ids.
+const (
+	// TemplateIDDefault is the Nygard-style template used unless another is requested
+	TemplateIDDefault = "default"
+	// TemplateIDMADR is the Markdown Architectural Decision Records template. see https://adr.github.io/madr/
+	TemplateIDMADR = "madr"
+)
+
 // DefaultTemplateForFormat retrieves the default template for a given DocumentFormat.
 // Default templates are expected to follow the naming pattern "default.{format}.tpl".
+// dirs are searched for overrides, as ListTemplates does.
 // Returns an error if no template matching the default pattern is found.
-func DefaultTemplateForFormat(format DocumentFormat) (*ParsedTemplateFile, error) {
-	tpls, err := ListTemplates()
+func DefaultTemplateForFormat(format DocumentFormat, dirs ...string) (*ParsedTemplateFile, error) {
+	return TemplateForFormat(TemplateIDDefault, format, dirs...)
+}
+
+// TemplateForFormat retrieves the template with the given id for a DocumentFormat.
+// Templates are expected to follow the naming pattern "{id}.{format}.tpl".
+// dirs are searched for overrides, as ListTemplates does.
+// Returns an error if no matching template is found.
+func TemplateForFormat(id string, format DocumentFormat, dirs ...string) (*ParsedTemplateFile, error) {
+	tpls, err := ListTemplates(dirs...)
 	if err != nil {
 		return nil, fmt.Errorf("error listing templates: %w", err)
 	}
 
-	defaultName := strings.Join([]string{"default", string(format), "tpl"}, ".")
+	name := strings.Join([]string{id, string(format), "tpl"}, ".")
 
-	v, ok := tpls[defaultName]
+	v, ok := tpls[name]
 	if !ok {
-		return nil, TemplateNotFoundError{TemplateName: defaultName}
+		return nil, TemplateNotFoundError{TemplateName: name}
 	}
 
 	return v, nil
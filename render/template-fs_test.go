@@ -1,7 +1,10 @@
 package render
 
 import (
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,3 +39,111 @@ func TestListTemplates_OnlyTemplates(t *testing.T) {
 		assert.Equal(t, ".tpl", ext)
 	}
 }
+
+func TestTemplateForFormat(t *testing.T) {
+	found, err := TemplateForFormat(TemplateIDMADR, DocumentFormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, "madr.markdown.tpl", found.Name)
+	assert.Equal(t, TemplateIDMADR, found.ID)
+
+	_, err = TemplateForFormat("nope", DocumentFormatMarkdown)
+	require.ErrorAs(t, err, &TemplateNotFoundError{})
+}
+
+func TestListTemplates_LocalOverrides(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		files      map[string]string
+		assertFunc func(t *testing.T, tpls map[string]*ParsedTemplateFile, err error)
+	}{
+		{
+			name: "local templates override and extend embedded ones",
+			files: map[string]string{
+				"default.markdown.tpl":     "# {{.Title}}",
+				"lightweight.markdown.tpl": "{{.Title}}",
+				"README.md":                "not a template",
+			},
+			assertFunc: func(t *testing.T, tpls map[string]*ParsedTemplateFile, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "# {{.Title}}", string(tpls["default.markdown.tpl"].Content))
+				assert.Equal(t, "lightweight", tpls["lightweight.markdown.tpl"].ID)
+				assert.Contains(t, tpls, "madr.markdown.tpl")
+				assert.NotContains(t, tpls, "README.md")
+			},
+		},
+		{
+			name:  "invalid local templates are an error",
+			files: map[string]string{"empty.markdown.tpl": ""},
+			assertFunc: func(t *testing.T, _ map[string]*ParsedTemplateFile, err error) {
+				require.ErrorContains(t, err, "empty.markdown.tpl")
+			},
+		},
+		{
+			name:  "unsupported formats are an error",
+			files: map[string]string{"default.docx.tpl": "{{.Title}}"},
+			assertFunc: func(t *testing.T, _ map[string]*ParsedTemplateFile, err error) {
+				require.ErrorContains(t, err, "unsupported format")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			}
+
+			tpls, err := ListTemplates(dir)
+			tt.assertFunc(t, tpls, err)
+		})
+	}
+}
+
+// a missing template directory isn't an error: most repos don't have one.
+func TestListTemplates_MissingDir(t *testing.T) {
+	tpls, err := ListTemplates(filepath.Join(t.TempDir(), LocalTemplatesDir), "")
+	require.NoError(t, err)
+	assert.Contains(t, tpls, "default.markdown.tpl")
+}
+
+// html templates escape values, text templates leave them alone.
+func TestParsedTemplateFile_Prepare(t *testing.T) {
+	tests := []struct {
+		format   DocumentFormat
+		expected string
+	}{
+		{format: DocumentFormatMarkdown, expected: "<p>a & b</p> [x](y.md) <p><em>hi</em></p>\n"},
+		{format: DocumentFormatHTML, expected: `<p>a &amp; b</p> <a href="y.md">x</a> <p><em>hi</em></p>` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			parsed := &ParsedTemplateFile{
+				ID:      "test",
+				Name:    "test." + string(tt.format) + ".tpl",
+				Format:  tt.format,
+				Content: []byte(`<p>{{.Text}}</p> {{link "x" "y.md"}} {{markdown .Markdown}}`),
+			}
+
+			tpl, err := parsed.Prepare(FuncMap(tt.format))
+			require.NoError(t, err)
+
+			var rendered strings.Builder
+			require.NoError(t, tpl.Execute(&rendered, map[string]string{"Text": "a & b", "Markdown": "*hi*"}))
+			assert.Equal(t, tt.expected, rendered.String())
+		})
+	}
+}
+
+func TestFormatForName(t *testing.T) {
+	for _, name := range []string{"asciidoc", "adoc", "AsciiDoc"} {
+		format, err := FormatForName(name)
+		require.NoError(t, err)
+		assert.Equal(t, DocumentFormatAsciiDoc, format)
+	}
+
+	_, err := FormatForName("docx")
+	require.Error(t, err)
+}
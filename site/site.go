@@ -0,0 +1,391 @@
+package site
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/globals"
+	"github.com/therealkevinard/adr-er/render"
+	"github.com/therealkevinard/adr-er/utils"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// AssetFS embeds the site's page templates and static assets. nothing is fetched at runtime, so generated sites work
+// fully offline.
+//
+//go:embed templates/*.html.tmpl static/*
+var AssetFS embed.FS
+
+// site layout.
+const (
+	// staticDir holds the css and js, relative to the site root
+	staticDir = "static"
+	// tagsDir holds the per-tag pages, relative to the site root
+	tagsDir = "tags"
+	// pageExtension is the extension of every generated page
+	pageExtension = ".html"
+)
+
+// hexColorPattern matches a css hex color.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Site renders a set of ADRs to static html.
+type Site struct {
+	// Title is shown in every page's header
+	Title string
+	// lifecycle normalizes statuses for display and filtering
+	lifecycle *adr.Lifecycle
+	// markdown converts ADR bodies to html
+	markdown goldmark.Markdown
+}
+
+// NewSite is a constructor.
+func NewSite(title string, lifecycle *adr.Lifecycle) *Site {
+	return &Site{
+		Title:     title,
+		lifecycle: lifecycle,
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithParserOptions(
+				parser.WithASTTransformers(util.Prioritized(linkRewriter{}, 0)),
+			),
+		),
+	}
+}
+
+// page is the data shared by every page's layout.
+type page struct {
+	// SiteTitle is the site-wide title
+	SiteTitle string
+	// Title is this page's title
+	Title string
+	// Root is the relative path from this page to the site root, eg: "" or "../"
+	Root string
+}
+
+// entry is a single ADR, as listed on the index and tag pages.
+type entry struct {
+	Sequence string
+	Title    string
+	Href     string
+	Status   string
+	Date     string
+	Tags     []tag
+}
+
+// tag is a link to a tag page.
+type tag struct {
+	Name string
+	Href string
+}
+
+// adrPage renders a single ADR.
+type adrPage struct {
+	page
+	Entry    entry
+	Authors  []string
+	Deciders []string
+	Body     template.HTML
+	Prev     *entry
+	Next     *entry
+}
+
+// indexPage lists every ADR.
+type indexPage struct {
+	page
+	Entries  []entry
+	Statuses []string
+	Tags     []tag
+}
+
+// tagPage lists the ADRs holding a tag.
+type tagPage struct {
+	page
+	Tag     string
+	Entries []entry
+}
+
+// Build renders files to outDir: a page per ADR, an index page, a page per tag, and the static assets.
+// existing files in outDir are overwritten, but nothing is removed.
+func (s *Site) Build(files []adr.File, outDir string) error {
+	if outDir == "" {
+		return globals.ValidationError("out", "output directory is empty")
+	}
+
+	//nolint:mnd // not magic, these are the standard permissions
+	if err := os.MkdirAll(filepath.Join(outDir, tagsDir), 0o755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	entries := make([]entry, len(files))
+	for i, file := range files {
+		entries[i] = s.entry(file)
+	}
+
+	// a page per ADR
+	for i, file := range files {
+		body, err := s.body(file)
+		if err != nil {
+			return err
+		}
+
+		data := adrPage{
+			page:     page{SiteTitle: s.Title, Title: file.SequencedTitle(), Root: ""},
+			Entry:    entries[i],
+			Authors:  file.Authors,
+			Deciders: file.Deciders,
+			Body:     body,
+			Prev:     nil,
+			Next:     nil,
+		}
+
+		if i > 0 {
+			data.Prev = &entries[i-1]
+		}
+
+		if i < len(entries)-1 {
+			data.Next = &entries[i+1]
+		}
+
+		if err = s.writePage(filepath.Join(outDir, entries[i].Href), "adr", data); err != nil {
+			return err
+		}
+	}
+
+	// the index
+	index := indexPage{
+		page:     page{SiteTitle: s.Title, Title: s.Title, Root: ""},
+		Entries:  entries,
+		Statuses: s.statuses(entries),
+		Tags:     tags(entries),
+	}
+	if err := s.writePage(filepath.Join(outDir, "index"+pageExtension), "index", index); err != nil {
+		return err
+	}
+
+	// a page per tag
+	for _, t := range index.Tags {
+		tagged := slices.DeleteFunc(slices.Clone(entries), func(e entry) bool {
+			return !slices.ContainsFunc(e.Tags, func(et tag) bool { return et.Href == t.Href })
+		})
+
+		data := tagPage{
+			page:    page{SiteTitle: s.Title, Title: "Tagged " + t.Name, Root: "../"},
+			Tag:     t.Name,
+			Entries: tagged,
+		}
+		if err := s.writePage(filepath.Join(outDir, filepath.FromSlash(t.Href)), "tag", data); err != nil {
+			return err
+		}
+	}
+
+	return copyStatic(outDir)
+}
+
+// entry builds the listing for a single file.
+func (s *Site) entry(file adr.File) entry {
+	fileTags := make([]tag, 0, len(file.Tags))
+	for _, name := range file.Tags {
+		fileTags = append(fileTags, tag{Name: name, Href: path.Join(tagsDir, utils.Slugify(name)+pageExtension)})
+	}
+
+	date := ""
+	if !file.Created.IsZero() {
+		date = file.Created.Format(time.DateOnly)
+	}
+
+	return entry{
+		Sequence: utils.PadValue(file.Sequence, globals.NumericPadWidth),
+		Title:    file.Title,
+		Href:     pageName(filepath.Base(file.Path)),
+		Status:   s.lifecycle.Label(file.Status),
+		Date:     date,
+		Tags:     fileTags,
+	}
+}
+
+// body renders a file's content, without front matter, as html. links between ADRs point at their pages.
+// markdown is converted, and html ADRs show their article. other formats are shown preformatted.
+func (s *Site) body(file adr.File) (template.HTML, error) {
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading ADR: %w", err)
+	}
+
+	content = adr.StripFrontMatter(content)
+
+	switch format, _ := render.FormatForExtension(filepath.Ext(file.Path)); format {
+	case render.DocumentFormatMarkdown:
+	case render.DocumentFormatHTML:
+		return articleHTML(content)
+	default:
+		return sourceHTML(format, content), nil
+	}
+
+	var converted bytes.Buffer
+	if err = s.markdown.Convert(content, &converted); err != nil {
+		return "", fmt.Errorf("error converting %s: %w", file.Path, err)
+	}
+
+	//nolint:gosec // goldmark escapes raw html by default
+	return template.HTML(converted.String()), nil
+}
+
+// statuses returns the statuses in use, in lifecycle order, followed by any unknown ones.
+func (s *Site) statuses(entries []entry) []string {
+	used := make([]string, 0)
+
+	for _, status := range s.lifecycle.Statuses() {
+		if slices.ContainsFunc(entries, func(e entry) bool { return e.Status == status }) {
+			used = append(used, status)
+		}
+	}
+
+	for _, e := range entries {
+		if e.Status != "" && !slices.Contains(used, e.Status) {
+			used = append(used, e.Status)
+		}
+	}
+
+	return used
+}
+
+// statusStyle returns an inline style coloring a status with its configured color, overriding the stylesheet.
+// only hex colors apply: ANSI color numbers have no css equivalent.
+func (s *Site) statusStyle(status string) template.CSS {
+	color := s.lifecycle.Color(status)
+	if !hexColorPattern.MatchString(color) {
+		return ""
+	}
+
+	//nolint:gosec // the color is validated
+	return template.CSS("background: " + color)
+}
+
+// tags returns every tag in use, sorted by name.
+// tags are grouped by their page, so spellings of one tag, eg: "Platform Team" and "platform-team", share it. the first
+// spelling found names the group.
+func tags(entries []entry) []tag {
+	found := make([]tag, 0)
+
+	for _, e := range entries {
+		for _, t := range e.Tags {
+			if !slices.ContainsFunc(found, func(f tag) bool { return f.Href == t.Href }) {
+				found = append(found, t)
+			}
+		}
+	}
+
+	slices.SortFunc(found, func(a, b tag) int { return strings.Compare(a.Name, b.Name) })
+
+	return found
+}
+
+// writePage renders the named page template, within the shared layout, to target.
+func (s *Site) writePage(target, name string, data any) error {
+	tpl, err := template.New(name).Funcs(template.FuncMap{
+		"statusClass": func(status string) string { return "status-" + utils.Slugify(status) },
+		"statusStyle": s.statusStyle,
+	}).ParseFS(AssetFS, "templates/layout.html.tmpl", "templates/"+name+".html.tmpl")
+	if err != nil {
+		return fmt.Errorf("error parsing %s template: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err = tpl.ExecuteTemplate(&rendered, "layout", data); err != nil {
+		return fmt.Errorf("error rendering %s: %w", target, err)
+	}
+
+	//nolint:mnd,gosec // not magic. the site is meant to be read
+	if err = os.WriteFile(target, rendered.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error writing %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// copyStatic writes the embedded static assets to outDir.
+func copyStatic(outDir string) error {
+	//nolint:mnd // not magic, these are the standard permissions
+	if err := os.MkdirAll(filepath.Join(outDir, staticDir), 0o755); err != nil {
+		return fmt.Errorf("error creating static directory: %w", err)
+	}
+
+	assets, err := fs.ReadDir(AssetFS, staticDir)
+	if err != nil {
+		return fmt.Errorf("error listing static assets: %w", err)
+	}
+
+	for _, asset := range assets {
+		content, readErr := AssetFS.ReadFile(path.Join(staticDir, asset.Name()))
+		if readErr != nil {
+			return fmt.Errorf("error reading %s: %w", asset.Name(), readErr)
+		}
+
+		//nolint:mnd,gosec // not magic. the site is meant to be read
+		if err = os.WriteFile(filepath.Join(outDir, staticDir, asset.Name()), content, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", asset.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// pageName is the page filename for an ADR filename, eg: "0001-use-go.md" becomes "0001-use-go.html".
+func pageName(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + pageExtension
+}
+
+// linkRewriter points relative links to other ADR documents at their generated pages.
+type linkRewriter struct{}
+
+// Transform implements parser.ASTTransformer.
+func (linkRewriter) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	//nolint:errcheck // the walker never errors
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := node.(*ast.Link); ok && entering {
+			link.Destination = []byte(pageDestination(string(link.Destination)))
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
+
+// pageDestination maps a link destination to its generated page, if it's a relative link to an ADR document.
+// anything else is returned as-is.
+func pageDestination(destination string) string {
+	target, err := url.Parse(destination)
+	if err != nil || target.Scheme != "" || target.Host != "" || target.Path == "" {
+		return destination
+	}
+
+	filename := path.Base(target.Path)
+	if _, seqErr := utils.SequenceFromFilename(filename); seqErr != nil {
+		return destination
+	}
+
+	if _, supported := render.FormatForExtension(path.Ext(filename)); !supported {
+		return destination
+	}
+
+	target.Path = path.Join(path.Dir(target.Path), pageName(filename))
+
+	return target.String()
+}
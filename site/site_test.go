@@ -0,0 +1,95 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/adr"
+)
+
+func TestBuild(t *testing.T) {
+	adrDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "public")
+
+	files := map[string]string{
+		"0001-use-go.md": "---\ntags:\n  - Platform Team\n---\n0001: Use Go\n---\n\n## Status: superseded by [0002: Use Rust](0002-use-rust.md)\n",
+		"0002-use-rust.md": "---\nauthors:\n  - ana\n---\n0002: Use Rust\n---\n\n## Status: accepted\n\n" +
+			"## Context\nsee [the old one](./0001-use-go.md#context) and <script>alert(1)</script>\n",
+		"0003-use-zig.md": "---\ntags:\n  - platform-team\n---\n0003: Use Zig\n---\n\n## Status: proposed\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(adrDir, name), []byte(content), 0o600))
+	}
+
+	loaded, err := adr.LoadDirectory(adrDir)
+	require.NoError(t, err)
+	require.NoError(t, NewSite("Decisions", adr.DefaultLifecycle()).Build(loaded, outDir))
+
+	read := func(name string) string {
+		t.Helper()
+
+		content, readErr := os.ReadFile(filepath.Join(outDir, name))
+		require.NoError(t, readErr)
+
+		return string(content)
+	}
+
+	index := read("index.html")
+	assert.Contains(t, index, `<a href="0001-use-go.html">Use Go</a>`)
+	assert.Contains(t, index, `<tr data-status="superseded">`)
+	assert.Contains(t, index, `data-filter="accepted"`)
+	assert.Contains(t, index, `<span class="status status-superseded" style="background: #6e7781">superseded</span>`)
+	assert.Contains(t, index, `<a class="tag" href="tags/platform-team.html">Platform Team</a>`)
+
+	first := read("0001-use-go.html")
+	assert.Contains(t, first, `<a href="0002-use-rust.html">0002: Use Rust</a>`)
+	assert.Contains(t, first, `<a class="next" href="0002-use-rust.html">`)
+	assert.NotContains(t, first, `class="prev"`)
+
+	// cross-links keep their fragments, and raw html is escaped
+	second := read("0002-use-rust.html")
+	assert.Contains(t, second, `href="0001-use-go.html#context"`)
+	assert.NotContains(t, second, "<script>alert")
+	assert.Contains(t, second, "<dd>ana</dd>")
+
+	tagged := read(filepath.Join("tags", "platform-team.html"))
+	assert.Contains(t, tagged, `<a href="../0001-use-go.html">Use Go</a>`)
+	assert.Contains(t, tagged, `<a href="../0003-use-zig.html">Use Zig</a>`)
+	assert.NotContains(t, tagged, "Use Rust")
+	assert.Contains(t, tagged, `href="../static/style.css"`)
+
+	assert.FileExists(t, filepath.Join(outDir, "static", "style.css"))
+	assert.FileExists(t, filepath.Join(outDir, "static", "site.js"))
+}
+
+func TestTags(t *testing.T) {
+	entries := []entry{
+		{Tags: []tag{{Name: "Platform Team", Href: "tags/platform-team.html"}, {Name: "infra", Href: "tags/infra.html"}}},
+		{Tags: []tag{{Name: "platform-team", Href: "tags/platform-team.html"}}},
+	}
+
+	assert.Equal(t, []tag{
+		{Name: "Platform Team", Href: "tags/platform-team.html"},
+		{Name: "infra", Href: "tags/infra.html"},
+	}, tags(entries))
+}
+
+func TestPageDestination(t *testing.T) {
+	tests := map[string]string{
+		"0001-use-go.md":                "0001-use-go.html",
+		"./0001-use-go.md#context":      "0001-use-go.html#context",
+		"https://example.com/0001-x.md": "https://example.com/0001-x.md",
+		"notes.md":                      "notes.md",
+		"0003-diagram.png":              "0003-diagram.png",
+		"#context":                      "#context",
+	}
+
+	for destination, expected := range tests {
+		t.Run(destination, func(t *testing.T) {
+			assert.Equal(t, expected, pageDestination(destination))
+		})
+	}
+}
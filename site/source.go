@@ -0,0 +1,126 @@
+package site
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/therealkevinard/adr-er/adr"
+	"github.com/therealkevinard/adr-er/render"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// sourceLinkPatterns match links in document source, by format. the first group is the link's target.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var sourceLinkPatterns = map[render.DocumentFormat]*regexp.Regexp{
+	render.DocumentFormatAsciiDoc: regexp.MustCompile(`(?:xref|link):([^\s\[]+)\[[^\]]*\]`),
+	render.DocumentFormatRST:      regexp.MustCompile("(?::doc:)?`[^`]*<([^`<>]+)>`_{0,2}"),
+	render.DocumentFormatOrg:      regexp.MustCompile(`\[\[(?:file:)?([^\]]+)\](?:\[[^\]]*\])?\]`),
+}
+
+// articleDroppedElements are active content, which the site shows nothing of.
+//
+//nolint:gochecknoglobals // this is a package-internal global by design
+var articleDroppedElements = []string{"embed", "iframe", "noscript", "object", "script", "style", "template"}
+
+// articleHTML renders the article of an html ADR as written, with links between ADRs pointing at their pages.
+// scripts, styles, and event handlers are dropped: the site shows documents, it doesn't run them.
+func articleHTML(content []byte) (template.HTML, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	nodes, err := html.ParseFragment(bytes.NewReader(adr.HTMLArticle(content)), body)
+	if err != nil {
+		return "", fmt.Errorf("error reading article: %w", err)
+	}
+
+	var rendered bytes.Buffer
+
+	for _, node := range nodes {
+		if !cleanArticleNode(node) {
+			continue
+		}
+
+		if err = html.Render(&rendered, node); err != nil {
+			return "", fmt.Errorf("error rendering article: %w", err)
+		}
+	}
+
+	//nolint:gosec // active content is dropped
+	return template.HTML(rendered.String()), nil
+}
+
+// cleanArticleNode drops active content under node and points its links at their pages.
+// Returns false if node itself is dropped.
+func cleanArticleNode(node *html.Node) bool {
+	if node.Type == html.ElementNode && slices.Contains(articleDroppedElements, node.Data) {
+		return false
+	}
+
+	node.Attr = slices.DeleteFunc(node.Attr, func(attr html.Attribute) bool {
+		return strings.HasPrefix(strings.ToLower(attr.Key), "on") ||
+			(attr.Key == "href" || attr.Key == "src") && strings.HasPrefix(strings.ToLower(attr.Val), "javascript:")
+	})
+
+	if node.Type == html.ElementNode && node.Data == "a" {
+		for i, attr := range node.Attr {
+			if attr.Key == "href" {
+				node.Attr[i].Val = pageDestination(attr.Val)
+			}
+		}
+	}
+
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if !cleanArticleNode(child) {
+			node.RemoveChild(child)
+		}
+
+		child = next
+	}
+
+	return true
+}
+
+// sourceHTML renders document source preformatted, with links to other ADRs made into links to their pages.
+func sourceHTML(format render.DocumentFormat, content []byte) template.HTML {
+	source := string(content)
+
+	var rendered strings.Builder
+
+	rendered.WriteString("<pre>")
+
+	last := 0
+
+	if pattern, ok := sourceLinkPatterns[format]; ok {
+		for _, match := range pattern.FindAllStringSubmatchIndex(source, -1) {
+			target := source[match[2]:match[3]]
+
+			// sphinx names documents without their extension
+			if format == render.DocumentFormatRST && path.Ext(target) == "" {
+				target += "." + format.Extension()
+			}
+
+			destination := pageDestination(target)
+			if destination == target {
+				continue
+			}
+
+			rendered.WriteString(template.HTMLEscapeString(source[last:match[0]]))
+			rendered.WriteString(`<a href="` + template.HTMLEscapeString(destination) + `">`)
+			rendered.WriteString(template.HTMLEscapeString(source[match[0]:match[1]]) + "</a>")
+
+			last = match[1]
+		}
+	}
+
+	rendered.WriteString(template.HTMLEscapeString(source[last:]) + "</pre>")
+
+	//nolint:gosec // the content is escaped
+	return template.HTML(rendered.String())
+}
@@ -0,0 +1,67 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/render"
+)
+
+func TestArticleHTML(t *testing.T) {
+	content := "<!DOCTYPE html>\n<html><head><style>body { color: red }</style></head><body><article>\n" +
+		"<h1>0002: Use Rust</h1>\n<p onclick=\"alert(1)\">see <a href=\"0001-use-go.html#context\">the old one</a>" +
+		"<script>alert(1)</script></p>\n" +
+		"<p><a href=\"javascript:alert(1)\">x</a> and <a href=\"https://example.com\">y</a></p>\n" +
+		"</article></body></html>\n"
+
+	rendered, err := articleHTML([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "\n<h1>0002: Use Rust</h1>\n<p>see <a href=\"0001-use-go.html#context\">the old one</a></p>\n"+
+		"<p><a>x</a> and <a href=\"https://example.com\">y</a></p>\n", string(rendered))
+}
+
+func TestSourceHTML(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		format     render.DocumentFormat
+		content    string
+		assertFunc func(t *testing.T, rendered string)
+	}{
+		{
+			name:    "asciidoc",
+			format:  render.DocumentFormatAsciiDoc,
+			content: "== Links\n\n* Amends xref:0001-use-go.adoc[0001: Use <Go>]\n* link:https://example.com[docs]\n",
+			assertFunc: func(t *testing.T, rendered string) {
+				assert.Equal(t, "<pre>== Links\n\n"+
+					"* Amends <a href=\"0001-use-go.html\">xref:0001-use-go.adoc[0001: Use &lt;Go&gt;]</a>\n"+
+					"* link:https://example.com[docs]\n</pre>", rendered)
+			},
+		},
+		{
+			name:    "rst",
+			format:  render.DocumentFormatRST,
+			content: "* Amends :doc:`0001: Use Go <0001-use-go>`\n* `docs <https://example.com>`__\n",
+			assertFunc: func(t *testing.T, rendered string) {
+				assert.Contains(t, rendered, "<a href=\"0001-use-go.html\">:doc:`0001: Use Go &lt;0001-use-go&gt;`</a>\n")
+				assert.Contains(t, rendered, "* `docs &lt;https://example.com&gt;`__\n")
+			},
+		},
+		{
+			name:    "org",
+			format:  render.DocumentFormatOrg,
+			content: "* Amends [[file:0001-use-go.org][0001: Use Go]]\n",
+			assertFunc: func(t *testing.T, rendered string) {
+				assert.Equal(t,
+					"<pre>* Amends <a href=\"0001-use-go.html\">[[file:0001-use-go.org][0001: Use Go]]</a>\n</pre>", rendered)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertFunc(t, string(sourceHTML(tt.format, []byte(tt.content))))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// status filters for the index page. without javascript, every decision is listed.
+(function () {
+  var buttons = document.querySelectorAll(".filter");
+  var rows = document.querySelectorAll("tr[data-status]");
+
+  buttons.forEach(function (button) {
+    button.addEventListener("click", function () {
+      var status = button.getAttribute("data-filter");
+
+      buttons.forEach(function (b) {
+        b.classList.toggle("active", b === button);
+      });
+
+      rows.forEach(function (row) {
+        row.hidden = status !== "" && row.getAttribute("data-status") !== status;
+      });
+    });
+  });
+})();
@@ -7,12 +7,48 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/therealkevinard/adr-er/globals"
 )
 
 // theme is a read-only singular instance of the Theme used across the application.
 // consumers can use the ApplicationTheme getter to reference it, which will create on demand.
 var theme *Theme
 
+// DefaultName is the name of the default huh theme.
+const DefaultName = "charm"
+
+// huhThemeName selects the huh theme the application theme is built on. see Configure.
+var huhThemeName = DefaultName
+
+// huhThemes maps theme names to the huh themes they build on.
+var huhThemes = map[string]func() *huh.Theme{
+	DefaultName:  huh.ThemeCharm,
+	"dracula":    huh.ThemeDracula,
+	"catppuccin": huh.ThemeCatppuccin,
+	"base16":     huh.ThemeBase16,
+	"base":       huh.ThemeBase,
+}
+
+// Lookup returns the huh theme constructor registered for name, and whether one exists.
+func Lookup(name string) (func() *huh.Theme, bool) {
+	build, ok := huhThemes[name]
+
+	return build, ok
+}
+
+// Configure selects the named theme for the application. it must be called before the theme is first used, as
+// ApplicationTheme builds the theme only once. Returns an error for unknown names.
+func Configure(name string) error {
+	if _, ok := Lookup(name); !ok {
+		return globals.ValidationError("theme", fmt.Sprintf("unknown theme %q", name))
+	}
+
+	huhThemeName = name
+	theme = nil
+
+	return nil
+}
+
 // ColorKey is a typed map-key const used for Theme.KeyColors.
 type ColorKey string
 
@@ -39,7 +75,7 @@ type Theme struct {
 // ApplicationTheme returns the singular theme instance.
 func ApplicationTheme() *Theme {
 	if theme == nil {
-		// init from huh.ThemeCharm.
+		// init from the configured huh theme, ThemeCharm by default.
 		// color keys ripped from ThemeCharm() constructor and hoisted to _our_ theme for re-use
 		var (
 			normalFg = lipgloss.AdaptiveColor{Light: "235", Dark: "252"}
@@ -52,7 +88,7 @@ func ApplicationTheme() *Theme {
 
 		//
 		theme = &Theme{
-			Theme:        huh.ThemeCharm(),
+			Theme:        huhThemes[huhThemeName](),
 			PrimaryColor: indigo,
 			AccentColor:  fuchsia,
 			KeyColors: map[ColorKey]lipgloss.TerminalColor{
@@ -73,6 +109,16 @@ func (t *Theme) TitleStyle() lipgloss.Style { return t.Focused.Title }
 
 func (t *Theme) HelpStyle() lipgloss.Style { return list.DefaultStyles().HelpStyle }
 
+// StatusStyle returns the style for displaying a status in color, a hex color or an ANSI color number.
+// an empty color is unstyled.
+func StatusStyle(color string) lipgloss.Style {
+	if color == "" {
+		return lipgloss.NewStyle()
+	}
+
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
 // RenderCancelMessage writes the very common "cancelled" message to the user.
 func (t *Theme) RenderCancelMessage() {
 	fmt.Println(t.TitleStyle().Render(
@@ -1,22 +1,26 @@
 package utils
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/therealkevinard/adr-er/globals"
 )
 
 // this regex will match existing ADR output files.
 // it follows the naming conventions outlined for output_templates.ParsedTemplateFile.
-// example matches: 0001-fizzy-pop.md, 0002-bubble-gupp.md, 0003-thing-two.txt.
+// example matches: 0001-fizzy-pop.md, 0002-bubble-gupp.adoc, 0003-thing-two.txt.
 var adrFileNamePattern = regexp.MustCompile(`^(\d+)-.+\.\w+$`)
 
 // LocateADRDirectory attempts to locate the correct directory to store ADRs, starting at root
 // root defaults to os.Getwd if empty.
+// an adr-tools `.adr-dir` file in root takes precedence over the conventional candidates.
 func LocateADRDirectory(root string) (string, error) {
 	// default to os.Getwd()
 	if root == "" {
@@ -28,6 +32,11 @@ func LocateADRDirectory(root string) (string, error) {
 		root = cwd
 	}
 
+	// an adr-tools config names the directory outright. it's trusted, like an explicit --dir.
+	if configured, ok := readADRDirConfig(root); ok {
+		return configured, nil
+	}
+
 	// candidate directory names. these directory names, within root, are considered candidates
 	// these are ordered by preference: the first one that passes all rules is used.
 	candidates := []string{
@@ -51,6 +60,48 @@ func LocateADRDirectory(root string) (string, error) {
 	return "", globals.ValidationError("adrDirectory", fmt.Sprintf("no viable ADR directory found under %s", root))
 }
 
+// ADRDirConfigFile is the file adr-tools uses to record the ADR directory, relative to the file itself.
+const ADRDirConfigFile = ".adr-dir"
+
+// LocateADRToolsDirectory finds the ADR directory of an adr-tools project rooted at root:
+// the directory named by its `.adr-dir` file, or adr-tools' default `doc/adr`. if neither exists, root itself is
+// returned, as it may be the ADR directory already.
+func LocateADRToolsDirectory(root string) string {
+	if configured, ok := readADRDirConfig(root); ok {
+		return configured
+	}
+
+	if info, err := os.Stat(filepath.Join(root, "doc", "adr")); err == nil && info.IsDir() {
+		return filepath.Join(root, "doc", "adr")
+	}
+
+	return root
+}
+
+// readADRDirConfig reads the adr-tools `.adr-dir` file in root, returning the directory it names.
+// Returns false if there's no such file, or it doesn't name an existing directory.
+func readADRDirConfig(root string) (string, bool) {
+	content, err := os.ReadFile(filepath.Join(root, ADRDirConfigFile))
+	if err != nil {
+		return "", false
+	}
+
+	dir := strings.TrimSpace(string(content))
+	if dir == "" {
+		return "", false
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+
+	if info, statErr := os.Stat(dir); statErr != nil || !info.IsDir() {
+		return "", false
+	}
+
+	return dir, true
+}
+
 // GetHighestSequenceNumber reads the filenames in root, extracting the ADR sequence number.
 // The highest existing value is returned.
 func GetHighestSequenceNumber(root string) (int, error) {
@@ -73,17 +124,14 @@ func GetHighestSequenceNumber(root string) (int, error) {
 			continue
 		}
 
-		// search filename using the ADR convention match pattern
-		matches := adrFileNamePattern.FindStringSubmatch(entry.Name())
-		//nolint:mnd // not magic
-		if len(matches) < 2 {
-			continue // No match or no captured group.
+		// skip files that don't follow the ADR naming convention
+		if !adrFileNamePattern.MatchString(entry.Name()) {
+			continue
 		}
 
-		// convert to int
-		sequence, convErr := strconv.Atoi(matches[1])
-		if convErr != nil {
-			return 0, fmt.Errorf("error parsing sequence number from %s: %w", entry.Name(), convErr)
+		sequence, seqErr := SequenceFromFilename(entry.Name())
+		if seqErr != nil {
+			return 0, seqErr
 		}
 
 		// update highest, if higher.
@@ -95,6 +143,131 @@ func GetHighestSequenceNumber(root string) (int, error) {
 	return highest, nil
 }
 
+// SequenceFromFilename extracts the ADR sequence number from a filename that follows the ADR naming convention.
+// eg: 0007-team-expansion.md returns 7.
+// Returns an error if the filename doesn't match the convention.
+func SequenceFromFilename(filename string) (int, error) {
+	matches := adrFileNamePattern.FindStringSubmatch(filepath.Base(filename))
+	//nolint:mnd // not magic
+	if len(matches) < 2 {
+		return 0, globals.ValidationError("filename", "does not follow the ADR naming convention")
+	}
+
+	sequence, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, fmt.Errorf("error parsing sequence number from %s: %w", filename, err)
+	}
+
+	return sequence, nil
+}
+
+// FindBySequence locates the ADR file with the given sequence number in root, returning its full path.
+// Returns an error if no file, or more than one file, carries the sequence.
+func FindBySequence(root string, sequence int) (string, error) {
+	if root == "" {
+		return "", globals.ValidationError("directory", "directory path is empty")
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", fmt.Errorf("error reading directory: %w", err)
+	}
+
+	var found []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !adrFileNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		if entrySequence, seqErr := SequenceFromFilename(entry.Name()); seqErr == nil && entrySequence == sequence {
+			found = append(found, filepath.Join(root, entry.Name()))
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", globals.ValidationError("sequence", fmt.Sprintf("no ADR with sequence %d in %s", sequence, root))
+	case 1:
+		return found[0], nil
+	default:
+		return "", globals.ValidationError("sequence", fmt.Sprintf("sequence %d is ambiguous: %d files share it", sequence, len(found)))
+	}
+}
+
+// ReplaceFile atomically replaces the content of the file at path.
+// content is written to a temporary sibling file that is renamed over the original, so readers never see a partial
+// write. the original file's permissions are preserved.
+func ReplaceFile(path string, content []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error accessing %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	// cleanup is a no-op once the rename succeeds
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error writing temporary file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temporary file: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("error setting permissions: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReplaceFiles atomically replaces the content of several files, keyed by path.
+// files are replaced one at a time. if any replacement fails, the files already replaced are restored to their original
+// content, so either every file is updated or none are.
+func ReplaceFiles(files map[string][]byte) error {
+	originals := make(map[string][]byte, len(files))
+
+	// read every original up front, so nothing is touched if any file is unreadable
+	for path := range files {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+
+		originals[path] = original
+	}
+
+	replaced := make([]string, 0, len(files))
+
+	for path, content := range files {
+		if err := ReplaceFile(path, content); err != nil {
+			// roll back
+			for _, done := range replaced {
+				if restoreErr := ReplaceFile(done, originals[done]); restoreErr != nil {
+					err = errors.Join(err, fmt.Errorf("error restoring %s: %w", done, restoreErr))
+				}
+			}
+
+			return err
+		}
+
+		replaced = append(replaced, path)
+	}
+
+	return nil
+}
+
 // DisplayShortpath creates a relative path from absolute.
 // this is used primarily for display, as absolute paths can _easily_ over-wrap.
 // for error cases, the absolute path is returned. this guarantees a usable return value.
@@ -117,9 +290,20 @@ func DisplayShortpath(absolutePath string) (string, error) {
 	return "./" + relativePath, nil
 }
 
+// isIndexFile reports whether the file at path holds an index written by `adr-er index`, by its markers.
+func isIndexFile(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(content, []byte(globals.IndexStartMarker)) &&
+		bytes.Contains(content, []byte(globals.IndexEndMarker))
+}
+
 // evaluateCandidate checks an os directory as a viable store for ADR files.
 // returns true if the directory is a valid candidate, otherwise false
-// a viable store must be either empty, or hold only ADR-named files. subdirectories are allowed.
+// a viable store must be either empty, or hold only ADR-named files and the ADR index. subdirectories are allowed.
 func evaluateCandidate(fullpath string) (bool, error) {
 	// read the contents
 	entries, err := os.ReadDir(fullpath)
@@ -140,6 +324,11 @@ func evaluateCandidate(fullpath string) (bool, error) {
 			continue
 		}
 
+		// the generated index is the one non-ADR file we expect to find, under any name
+		if entry.Name() == globals.IndexFileName || isIndexFile(filepath.Join(fullpath, entry.Name())) {
+			continue
+		}
+
 		// if any file fails the regex match, the directory isn't a candidate.
 		if !adrFileNamePattern.MatchString(entry.Name()) {
 			return false, nil
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therealkevinard/adr-er/globals"
+)
+
+func TestLocateADRDirectory(t *testing.T) {
+	//nolint:thelper // false positive. these aren't helpers, they _are_ the tests
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, root string)
+		assertFunc func(t *testing.T, root, located string, err error)
+	}{
+		{
+			name: "conventional directory",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(root, "adr", "README.md"), []byte("index"), 0o600))
+			},
+			assertFunc: func(t *testing.T, root, located string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(root, "adr"), located)
+			},
+		},
+		{
+			name: "adr-tools config wins",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.MkdirAll(filepath.Join(root, "docs", "decisions"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(root, ADRDirConfigFile), []byte("docs/decisions\n"), 0o600))
+			},
+			assertFunc: func(t *testing.T, root, located string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(root, "docs", "decisions"), located)
+			},
+		},
+		{
+			name: "adr-tools config naming a missing directory",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.WriteFile(filepath.Join(root, ADRDirConfigFile), []byte("nowhere"), 0o600))
+			},
+			assertFunc: func(t *testing.T, _, located string, err error) {
+				require.Error(t, err)
+				assert.Empty(t, located)
+			},
+		},
+		{
+			name: "asciidoc ADRs",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(root, "adr", "0001-use-antora.adoc"), []byte("= Use Antora"), 0o600))
+			},
+			assertFunc: func(t *testing.T, root, located string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(root, "adr"), located)
+			},
+		},
+		{
+			name: "index written under another name",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.WriteFile(
+					filepath.Join(root, "adr", "INDEX.md"),
+					[]byte("# ADRs\n"+globals.IndexStartMarker+"\n| table |\n"+globals.IndexEndMarker+"\n"),
+					0o600,
+				))
+			},
+			assertFunc: func(t *testing.T, root, located string, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(root, "adr"), located)
+			},
+		},
+		{
+			name: "markdown without index markers",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(root, "adr", "NOTES.md"), []byte(globals.IndexStartMarker), 0o600))
+			},
+			assertFunc: func(t *testing.T, _, located string, err error) {
+				require.Error(t, err)
+				assert.Empty(t, located)
+			},
+		},
+		{
+			name: "non-adr files",
+			setup: func(t *testing.T, root string) {
+				require.NoError(t, os.Mkdir(filepath.Join(root, "adr"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(root, "adr", "main.go"), []byte("package main"), 0o600))
+			},
+			assertFunc: func(t *testing.T, _, located string, err error) {
+				require.Error(t, err)
+				assert.Empty(t, located)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			test.setup(t, root)
+
+			located, err := LocateADRDirectory(root)
+			test.assertFunc(t, root, located, err)
+		})
+	}
+}
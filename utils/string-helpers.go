@@ -39,3 +39,17 @@ func Slugify(input string) string {
 
 	return slug
 }
+
+// SplitList splits a comma-separated string into its trimmed, non-empty values.
+// eg: "alice, bob,,carol " returns ["alice", "bob", "carol"].
+func SplitList(input string) []string {
+	var values []string
+
+	for _, value := range strings.Split(input, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -124,3 +125,34 @@ func TestSlugifySlice(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+		args string
+	}{
+		{
+			name: "empty",
+			want: nil,
+			args: "",
+		},
+		{
+			name: "basic",
+			want: []string{"alice", "bob"},
+			args: "alice,bob",
+		},
+		{
+			name: "untidy",
+			want: []string{"alice", "bob", "carol"},
+			args: " alice, bob,,carol , ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitList(tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}